@@ -0,0 +1,436 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: approval.proto
+
+package approval
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type User struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name                 string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *User) Reset()         { *m = User{} }
+func (m *User) String() string { return proto.CompactTextString(m) }
+func (*User) ProtoMessage()    {}
+func (*User) Descriptor() ([]byte, []int) {
+	return fileDescriptor_317f9b72348dd733, []int{0}
+}
+
+func (m *User) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_User.Unmarshal(m, b)
+}
+func (m *User) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_User.Marshal(b, m, deterministic)
+}
+func (m *User) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_User.Merge(m, src)
+}
+func (m *User) XXX_Size() int {
+	return xxx_messageInfo_User.Size(m)
+}
+func (m *User) XXX_DiscardUnknown() {
+	xxx_messageInfo_User.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_User proto.InternalMessageInfo
+
+func (m *User) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *User) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type CreateApprovalRequestRequest struct {
+	Id             string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	From           string   `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	Approvers      []string `protobuf:"bytes,3,rep,name=approvers,proto3" json:"approvers,omitempty"`
+	To             []*User  `protobuf:"bytes,4,rep,name=to,proto3" json:"to,omitempty"`
+	FileId         string   `protobuf:"bytes,5,opt,name=fileId,proto3" json:"fileId,omitempty"`
+	FileName       string   `protobuf:"bytes,6,opt,name=fileName,proto3" json:"fileName,omitempty"`
+	Info           string   `protobuf:"bytes,7,opt,name=info,proto3" json:"info,omitempty"`
+	Classification string   `protobuf:"bytes,8,opt,name=classification,proto3" json:"classification,omitempty"`
+	// expiresAt is the time the permits expire at in unix milliseconds, 0 if they never expire.
+	ExpiresAt            int64    `protobuf:"varint,9,opt,name=expiresAt,proto3" json:"expiresAt,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateApprovalRequestRequest) Reset()         { *m = CreateApprovalRequestRequest{} }
+func (m *CreateApprovalRequestRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateApprovalRequestRequest) ProtoMessage()    {}
+func (*CreateApprovalRequestRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_317f9b72348dd733, []int{1}
+}
+
+func (m *CreateApprovalRequestRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateApprovalRequestRequest.Unmarshal(m, b)
+}
+func (m *CreateApprovalRequestRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateApprovalRequestRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateApprovalRequestRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateApprovalRequestRequest.Merge(m, src)
+}
+func (m *CreateApprovalRequestRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateApprovalRequestRequest.Size(m)
+}
+func (m *CreateApprovalRequestRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateApprovalRequestRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateApprovalRequestRequest proto.InternalMessageInfo
+
+func (m *CreateApprovalRequestRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *CreateApprovalRequestRequest) GetFrom() string {
+	if m != nil {
+		return m.From
+	}
+	return ""
+}
+
+func (m *CreateApprovalRequestRequest) GetApprovers() []string {
+	if m != nil {
+		return m.Approvers
+	}
+	return nil
+}
+
+func (m *CreateApprovalRequestRequest) GetTo() []*User {
+	if m != nil {
+		return m.To
+	}
+	return nil
+}
+
+func (m *CreateApprovalRequestRequest) GetFileId() string {
+	if m != nil {
+		return m.FileId
+	}
+	return ""
+}
+
+func (m *CreateApprovalRequestRequest) GetFileName() string {
+	if m != nil {
+		return m.FileName
+	}
+	return ""
+}
+
+func (m *CreateApprovalRequestRequest) GetInfo() string {
+	if m != nil {
+		return m.Info
+	}
+	return ""
+}
+
+func (m *CreateApprovalRequestRequest) GetClassification() string {
+	if m != nil {
+		return m.Classification
+	}
+	return ""
+}
+
+func (m *CreateApprovalRequestRequest) GetExpiresAt() int64 {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return 0
+}
+
+type CreateApprovalRequestResponse struct {
+	// id is the identifier the approval service gave the approval request.
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateApprovalRequestResponse) Reset()         { *m = CreateApprovalRequestResponse{} }
+func (m *CreateApprovalRequestResponse) String() string { return proto.CompactTextString(m) }
+func (*CreateApprovalRequestResponse) ProtoMessage()    {}
+func (*CreateApprovalRequestResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_317f9b72348dd733, []int{2}
+}
+
+func (m *CreateApprovalRequestResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateApprovalRequestResponse.Unmarshal(m, b)
+}
+func (m *CreateApprovalRequestResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateApprovalRequestResponse.Marshal(b, m, deterministic)
+}
+func (m *CreateApprovalRequestResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateApprovalRequestResponse.Merge(m, src)
+}
+func (m *CreateApprovalRequestResponse) XXX_Size() int {
+	return xxx_messageInfo_CreateApprovalRequestResponse.Size(m)
+}
+func (m *CreateApprovalRequestResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateApprovalRequestResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateApprovalRequestResponse proto.InternalMessageInfo
+
+func (m *CreateApprovalRequestResponse) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type CancelApprovalRequestRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CancelApprovalRequestRequest) Reset()         { *m = CancelApprovalRequestRequest{} }
+func (m *CancelApprovalRequestRequest) String() string { return proto.CompactTextString(m) }
+func (*CancelApprovalRequestRequest) ProtoMessage()    {}
+func (*CancelApprovalRequestRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_317f9b72348dd733, []int{3}
+}
+
+func (m *CancelApprovalRequestRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CancelApprovalRequestRequest.Unmarshal(m, b)
+}
+func (m *CancelApprovalRequestRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CancelApprovalRequestRequest.Marshal(b, m, deterministic)
+}
+func (m *CancelApprovalRequestRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CancelApprovalRequestRequest.Merge(m, src)
+}
+func (m *CancelApprovalRequestRequest) XXX_Size() int {
+	return xxx_messageInfo_CancelApprovalRequestRequest.Size(m)
+}
+func (m *CancelApprovalRequestRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CancelApprovalRequestRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CancelApprovalRequestRequest proto.InternalMessageInfo
+
+func (m *CancelApprovalRequestRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type CancelApprovalRequestResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CancelApprovalRequestResponse) Reset()         { *m = CancelApprovalRequestResponse{} }
+func (m *CancelApprovalRequestResponse) String() string { return proto.CompactTextString(m) }
+func (*CancelApprovalRequestResponse) ProtoMessage()    {}
+func (*CancelApprovalRequestResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_317f9b72348dd733, []int{4}
+}
+
+func (m *CancelApprovalRequestResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CancelApprovalRequestResponse.Unmarshal(m, b)
+}
+func (m *CancelApprovalRequestResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CancelApprovalRequestResponse.Marshal(b, m, deterministic)
+}
+func (m *CancelApprovalRequestResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CancelApprovalRequestResponse.Merge(m, src)
+}
+func (m *CancelApprovalRequestResponse) XXX_Size() int {
+	return xxx_messageInfo_CancelApprovalRequestResponse.Size(m)
+}
+func (m *CancelApprovalRequestResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CancelApprovalRequestResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CancelApprovalRequestResponse proto.InternalMessageInfo
+
+func init() {
+	proto.RegisterType((*User)(nil), "approval.User")
+	proto.RegisterType((*CreateApprovalRequestRequest)(nil), "approval.CreateApprovalRequestRequest")
+	proto.RegisterType((*CreateApprovalRequestResponse)(nil), "approval.CreateApprovalRequestResponse")
+	proto.RegisterType((*CancelApprovalRequestRequest)(nil), "approval.CancelApprovalRequestRequest")
+	proto.RegisterType((*CancelApprovalRequestResponse)(nil), "approval.CancelApprovalRequestResponse")
+}
+
+func init() { proto.RegisterFile("approval.proto", fileDescriptor_317f9b72348dd733) }
+
+var fileDescriptor_317f9b72348dd733 = []byte{
+	// 314 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x92, 0x4f, 0x4e, 0xeb, 0x30,
+	0x10, 0xc6, 0x5f, 0x9c, 0xbe, 0x92, 0x0c, 0x52, 0x16, 0x96, 0x40, 0x56, 0xd5, 0x42, 0xe4, 0x45,
+	0x1b, 0xb1, 0x28, 0x52, 0x39, 0x41, 0xd5, 0x15, 0x1b, 0x16, 0x91, 0x38, 0x80, 0x69, 0x27, 0x92,
+	0x51, 0x1a, 0x07, 0xdb, 0x20, 0x4e, 0xc1, 0x21, 0x39, 0x09, 0xb2, 0xd3, 0x34, 0xa2, 0x4a, 0x5b,
+	0x56, 0x99, 0x7f, 0x99, 0xef, 0xe7, 0xcf, 0x86, 0x44, 0xd4, 0xb5, 0x56, 0x1f, 0xa2, 0x9c, 0xd7,
+	0x5a, 0x59, 0x45, 0xa3, 0x36, 0xe7, 0x77, 0x30, 0x78, 0x36, 0xa8, 0x69, 0x02, 0x44, 0x6e, 0x58,
+	0x90, 0x06, 0x59, 0x9c, 0x13, 0xb9, 0xa1, 0x14, 0x06, 0x95, 0xd8, 0x22, 0x23, 0xbe, 0xe2, 0x63,
+	0xfe, 0x45, 0x60, 0xbc, 0xd2, 0x28, 0x2c, 0x2e, 0x77, 0xbf, 0xe7, 0xf8, 0xf6, 0x8e, 0xc6, 0xee,
+	0x3e, 0x7d, 0x4b, 0x0a, 0xad, 0xb6, 0xed, 0x12, 0x17, 0xd3, 0x31, 0xc4, 0x8d, 0x38, 0x6a, 0xc3,
+	0xc2, 0x34, 0xcc, 0xe2, 0xbc, 0x2b, 0xd0, 0x1b, 0x20, 0x56, 0xb1, 0x41, 0x1a, 0x66, 0x97, 0x8b,
+	0x64, 0xbe, 0xa7, 0x76, 0x88, 0x39, 0xb1, 0x8a, 0x5e, 0xc3, 0xb0, 0x90, 0x25, 0x3e, 0x6e, 0xd8,
+	0x7f, 0xbf, 0x73, 0x97, 0xd1, 0x11, 0x44, 0x2e, 0x7a, 0x72, 0xc8, 0x43, 0xdf, 0xd9, 0xe7, 0x8e,
+	0x42, 0x56, 0x85, 0x62, 0x17, 0x0d, 0x85, 0x8b, 0xe9, 0x14, 0x92, 0x75, 0x29, 0x8c, 0x91, 0x85,
+	0x5c, 0x0b, 0x2b, 0x55, 0xc5, 0x22, 0xdf, 0x3d, 0xa8, 0x3a, 0x5a, 0xfc, 0xac, 0xa5, 0x46, 0xb3,
+	0xb4, 0x2c, 0x4e, 0x83, 0x2c, 0xcc, 0xbb, 0x02, 0xbf, 0x87, 0xc9, 0x11, 0x3f, 0x4c, 0xad, 0x2a,
+	0x83, 0x87, 0x86, 0xf0, 0x39, 0x8c, 0x57, 0xa2, 0x5a, 0x63, 0xf9, 0x37, 0x03, 0xf9, 0x2d, 0x4c,
+	0x8e, 0xcc, 0x37, 0x02, 0x8b, 0xef, 0x00, 0xa2, 0xb6, 0x47, 0x5f, 0xe1, 0xaa, 0x17, 0x87, 0x4e,
+	0x3b, 0x27, 0x4f, 0xdd, 0xdf, 0x68, 0x76, 0x76, 0xae, 0x91, 0xe5, 0xff, 0xbc, 0x56, 0x1f, 0xd9,
+	0x2f, 0xad, 0x13, 0x47, 0x1d, 0xcd, 0xce, 0xce, 0xb5, 0x5a, 0x2f, 0x43, 0xff, 0x68, 0x1f, 0x7e,
+	0x06, 0x00, 0x06, 0xfa, 0x6e, 0xa3, 0xc6, 0x02, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// ApprovalClient is the client API for Approval service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ApprovalClient interface {
+	CreateApprovalRequest(ctx context.Context, in *CreateApprovalRequestRequest, opts ...grpc.CallOption) (*CreateApprovalRequestResponse, error)
+	CancelApprovalRequest(ctx context.Context, in *CancelApprovalRequestRequest, opts ...grpc.CallOption) (*CancelApprovalRequestResponse, error)
+}
+
+type approvalClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewApprovalClient(cc *grpc.ClientConn) ApprovalClient {
+	return &approvalClient{cc}
+}
+
+func (c *approvalClient) CreateApprovalRequest(ctx context.Context, in *CreateApprovalRequestRequest, opts ...grpc.CallOption) (*CreateApprovalRequestResponse, error) {
+	out := new(CreateApprovalRequestResponse)
+	err := c.cc.Invoke(ctx, "/approval.Approval/CreateApprovalRequest", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *approvalClient) CancelApprovalRequest(ctx context.Context, in *CancelApprovalRequestRequest, opts ...grpc.CallOption) (*CancelApprovalRequestResponse, error) {
+	out := new(CancelApprovalRequestResponse)
+	err := c.cc.Invoke(ctx, "/approval.Approval/CancelApprovalRequest", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ApprovalServer is the server API for Approval service.
+type ApprovalServer interface {
+	CreateApprovalRequest(context.Context, *CreateApprovalRequestRequest) (*CreateApprovalRequestResponse, error)
+	CancelApprovalRequest(context.Context, *CancelApprovalRequestRequest) (*CancelApprovalRequestResponse, error)
+}
+
+// UnimplementedApprovalServer can be embedded to have forward compatible implementations.
+type UnimplementedApprovalServer struct {
+}
+
+func (*UnimplementedApprovalServer) CreateApprovalRequest(ctx context.Context, req *CreateApprovalRequestRequest) (*CreateApprovalRequestResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateApprovalRequest not implemented")
+}
+func (*UnimplementedApprovalServer) CancelApprovalRequest(ctx context.Context, req *CancelApprovalRequestRequest) (*CancelApprovalRequestResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelApprovalRequest not implemented")
+}
+
+func RegisterApprovalServer(s *grpc.Server, srv ApprovalServer) {
+	s.RegisterService(&_Approval_serviceDesc, srv)
+}
+
+func _Approval_CreateApprovalRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateApprovalRequestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApprovalServer).CreateApprovalRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/approval.Approval/CreateApprovalRequest",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApprovalServer).CreateApprovalRequest(ctx, req.(*CreateApprovalRequestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Approval_CancelApprovalRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelApprovalRequestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApprovalServer).CancelApprovalRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/approval.Approval/CancelApprovalRequest",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApprovalServer).CancelApprovalRequest(ctx, req.(*CancelApprovalRequestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Approval_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "approval.Approval",
+	HandlerType: (*ApprovalServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateApprovalRequest",
+			Handler:    _Approval_CreateApprovalRequest_Handler,
+		},
+		{
+			MethodName: "CancelApprovalRequest",
+			Handler:    _Approval_CancelApprovalRequest_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "approval.proto",
+}
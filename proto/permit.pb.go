@@ -24,14 +24,174 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
+// UserResultStatus is the result of creating the permit of a user of a request.
+type UserResultStatus int32
+
+const (
+	UserResultStatus_USER_RESULT_NONE UserResultStatus = 0
+	// USER_RESULT_CREATED is the result of a user whose permit was created.
+	UserResultStatus_USER_RESULT_CREATED UserResultStatus = 1
+	// USER_RESULT_ALREADY_APPROVED is the result of a user who already has an approved permit to the file.
+	UserResultStatus_USER_RESULT_ALREADY_APPROVED UserResultStatus = 2
+	// USER_RESULT_SKIPPED is the result of a user that appears in the request more than once.
+	UserResultStatus_USER_RESULT_SKIPPED UserResultStatus = 3
+	// USER_RESULT_FAILED is the result of a user whose permit was created but failed to submit for approval.
+	UserResultStatus_USER_RESULT_FAILED UserResultStatus = 4
+)
+
+var UserResultStatus_name = map[int32]string{
+	0: "USER_RESULT_NONE",
+	1: "USER_RESULT_CREATED",
+	2: "USER_RESULT_ALREADY_APPROVED",
+	3: "USER_RESULT_SKIPPED",
+	4: "USER_RESULT_FAILED",
+}
+
+var UserResultStatus_value = map[string]int32{
+	"USER_RESULT_NONE":             0,
+	"USER_RESULT_CREATED":          1,
+	"USER_RESULT_ALREADY_APPROVED": 2,
+	"USER_RESULT_SKIPPED":          3,
+	"USER_RESULT_FAILED":           4,
+}
+
+func (x UserResultStatus) String() string {
+	return proto.EnumName(UserResultStatus_name, int32(x))
+}
+
+func (UserResultStatus) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_727fd833651e2ed7, []int{0}
+}
+
+// SubmissionStatus is the outcome of submitting the approval request of a request.
+type SubmissionStatus int32
+
+const (
+	SubmissionStatus_SUBMISSION_NONE SubmissionStatus = 0
+	// SUBMISSION_SUBMITTED is the outcome of an approval request the approval service accepted.
+	SubmissionStatus_SUBMISSION_SUBMITTED SubmissionStatus = 1
+	// SUBMISSION_QUEUED is the outcome of an approval request that failed to submit and will be retried.
+	SubmissionStatus_SUBMISSION_QUEUED SubmissionStatus = 2
+	// SUBMISSION_REJECTED is the outcome of an approval request the approval service rejected.
+	SubmissionStatus_SUBMISSION_REJECTED SubmissionStatus = 3
+)
+
+var SubmissionStatus_name = map[int32]string{
+	0: "SUBMISSION_NONE",
+	1: "SUBMISSION_SUBMITTED",
+	2: "SUBMISSION_QUEUED",
+	3: "SUBMISSION_REJECTED",
+}
+
+var SubmissionStatus_value = map[string]int32{
+	"SUBMISSION_NONE":      0,
+	"SUBMISSION_SUBMITTED": 1,
+	"SUBMISSION_QUEUED":    2,
+	"SUBMISSION_REJECTED":  3,
+}
+
+func (x SubmissionStatus) String() string {
+	return proto.EnumName(SubmissionStatus_name, int32(x))
+}
+
+func (SubmissionStatus) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_727fd833651e2ed7, []int{1}
+}
+
+// PermitStatus is the status of a permit, permits are stored and updated
+// with the lowercase name of the status without its prefix, i.e. "approved".
+type PermitStatus int32
+
+const (
+	PermitStatus_STATUS_PENDING   PermitStatus = 0
+	PermitStatus_STATUS_APPROVED  PermitStatus = 1
+	PermitStatus_STATUS_DENIED    PermitStatus = 2
+	PermitStatus_STATUS_REVOKED   PermitStatus = 3
+	PermitStatus_STATUS_EXPIRED   PermitStatus = 4
+	PermitStatus_STATUS_CANCELLED PermitStatus = 5
+	// STATUS_FAILED_TO_SUBMIT is the status of a permit whose approval request was rejected
+	// by the approval service.
+	PermitStatus_STATUS_FAILED_TO_SUBMIT PermitStatus = 6
+)
+
+var PermitStatus_name = map[int32]string{
+	0: "STATUS_PENDING",
+	1: "STATUS_APPROVED",
+	2: "STATUS_DENIED",
+	3: "STATUS_REVOKED",
+	4: "STATUS_EXPIRED",
+	5: "STATUS_CANCELLED",
+	6: "STATUS_FAILED_TO_SUBMIT",
+}
+
+var PermitStatus_value = map[string]int32{
+	"STATUS_PENDING":          0,
+	"STATUS_APPROVED":         1,
+	"STATUS_DENIED":           2,
+	"STATUS_REVOKED":          3,
+	"STATUS_EXPIRED":          4,
+	"STATUS_CANCELLED":        5,
+	"STATUS_FAILED_TO_SUBMIT": 6,
+}
+
+func (x PermitStatus) String() string {
+	return proto.EnumName(PermitStatus_name, int32(x))
+}
+
+func (PermitStatus) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_727fd833651e2ed7, []int{2}
+}
+
+// Decision is the outcome of evaluating a user's permit of a file.
+type Decision int32
+
+const (
+	Decision_DECISION_NONE    Decision = 0
+	Decision_DECISION_GRANTED Decision = 1
+	Decision_DECISION_PENDING Decision = 2
+	Decision_DECISION_DENIED  Decision = 3
+	Decision_DECISION_EXPIRED Decision = 4
+)
+
+var Decision_name = map[int32]string{
+	0: "DECISION_NONE",
+	1: "DECISION_GRANTED",
+	2: "DECISION_PENDING",
+	3: "DECISION_DENIED",
+	4: "DECISION_EXPIRED",
+}
+
+var Decision_value = map[string]int32{
+	"DECISION_NONE":    0,
+	"DECISION_GRANTED": 1,
+	"DECISION_PENDING": 2,
+	"DECISION_DENIED":  3,
+	"DECISION_EXPIRED": 4,
+}
+
+func (x Decision) String() string {
+	return proto.EnumName(Decision_name, int32(x))
+}
+
+func (Decision) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_727fd833651e2ed7, []int{3}
+}
+
 type CreatePermitRequest struct {
-	FileID               string   `protobuf:"bytes,1,opt,name=fileID,proto3" json:"fileID,omitempty"`
-	SharerID             string   `protobuf:"bytes,2,opt,name=sharerID,proto3" json:"sharerID,omitempty"`
-	Users                []*User  `protobuf:"bytes,3,rep,name=users,proto3" json:"users,omitempty"`
-	Classification       string   `protobuf:"bytes,4,opt,name=classification,proto3" json:"classification,omitempty"`
-	Info                 string   `protobuf:"bytes,5,opt,name=info,proto3" json:"info,omitempty"`
-	Approvers            []string `protobuf:"bytes,6,rep,name=approvers,proto3" json:"approvers,omitempty"`
-	FileName             string   `protobuf:"bytes,7,opt,name=fileName,proto3" json:"fileName,omitempty"`
+	FileID         string   `protobuf:"bytes,1,opt,name=fileID,proto3" json:"fileID,omitempty"`
+	SharerID       string   `protobuf:"bytes,2,opt,name=sharerID,proto3" json:"sharerID,omitempty"`
+	Users          []*User  `protobuf:"bytes,3,rep,name=users,proto3" json:"users,omitempty"`
+	Classification string   `protobuf:"bytes,4,opt,name=classification,proto3" json:"classification,omitempty"`
+	Info           string   `protobuf:"bytes,5,opt,name=info,proto3" json:"info,omitempty"`
+	Approvers      []string `protobuf:"bytes,6,rep,name=approvers,proto3" json:"approvers,omitempty"`
+	FileName       string   `protobuf:"bytes,7,opt,name=fileName,proto3" json:"fileName,omitempty"`
+	// expiresAt is the time in unix milliseconds at which the permits stop granting access.
+	ExpiresAt int64 `protobuf:"varint,8,opt,name=expiresAt,proto3" json:"expiresAt,omitempty"`
+	// ttl is the number of seconds from creation after which the permits stop granting access.
+	Ttl int64 `protobuf:"varint,9,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	// idempotencyKey identifies the request among the requests of its sharer, retrying a request with
+	// the same key returns the reqID of the original request instead of creating the permits again.
+	IdempotencyKey       string   `protobuf:"bytes,10,opt,name=idempotencyKey,proto3" json:"idempotencyKey,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -111,6 +271,27 @@ func (m *CreatePermitRequest) GetFileName() string {
 	return ""
 }
 
+func (m *CreatePermitRequest) GetExpiresAt() int64 {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return 0
+}
+
+func (m *CreatePermitRequest) GetTtl() int64 {
+	if m != nil {
+		return m.Ttl
+	}
+	return 0
+}
+
+func (m *CreatePermitRequest) GetIdempotencyKey() string {
+	if m != nil {
+		return m.IdempotencyKey
+	}
+	return ""
+}
+
 type User struct {
 	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	FullName             string   `protobuf:"bytes,2,opt,name=full_name,json=fullName,proto3" json:"full_name,omitempty"`
@@ -159,9 +340,13 @@ func (m *User) GetFullName() string {
 }
 
 type CreatePermitResponse struct {
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	ReqID string `protobuf:"bytes,1,opt,name=reqID,proto3" json:"reqID,omitempty"`
+	// results holds the result of each of the users of the request, in the order of the request's users.
+	Results              []*UserResult       `protobuf:"bytes,2,rep,name=results,proto3" json:"results,omitempty"`
+	Approval             *ApprovalSubmission `protobuf:"bytes,3,opt,name=approval,proto3" json:"approval,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
 }
 
 func (m *CreatePermitResponse) Reset()         { *m = CreatePermitResponse{} }
@@ -189,19 +374,167 @@ func (m *CreatePermitResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_CreatePermitResponse proto.InternalMessageInfo
 
-type UpdatePermitStatusRequest struct {
-	ReqID                string   `protobuf:"bytes,1,opt,name=reqID,proto3" json:"reqID,omitempty"`
-	Status               string   `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+func (m *CreatePermitResponse) GetReqID() string {
+	if m != nil {
+		return m.ReqID
+	}
+	return ""
+}
+
+func (m *CreatePermitResponse) GetResults() []*UserResult {
+	if m != nil {
+		return m.Results
+	}
+	return nil
+}
+
+func (m *CreatePermitResponse) GetApproval() *ApprovalSubmission {
+	if m != nil {
+		return m.Approval
+	}
+	return nil
+}
+
+type UserResult struct {
+	UserID string           `protobuf:"bytes,1,opt,name=userID,proto3" json:"userID,omitempty"`
+	Result UserResultStatus `protobuf:"varint,2,opt,name=result,proto3,enum=permit.UserResultStatus" json:"result,omitempty"`
+	// status is the status of the user's permit.
+	Status               string   `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	Reason               string   `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UserResult) Reset()         { *m = UserResult{} }
+func (m *UserResult) String() string { return proto.CompactTextString(m) }
+func (*UserResult) ProtoMessage()    {}
+func (*UserResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_727fd833651e2ed7, []int{3}
+}
+
+func (m *UserResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UserResult.Unmarshal(m, b)
+}
+func (m *UserResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UserResult.Marshal(b, m, deterministic)
+}
+func (m *UserResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UserResult.Merge(m, src)
+}
+func (m *UserResult) XXX_Size() int {
+	return xxx_messageInfo_UserResult.Size(m)
+}
+func (m *UserResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_UserResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UserResult proto.InternalMessageInfo
+
+func (m *UserResult) GetUserID() string {
+	if m != nil {
+		return m.UserID
+	}
+	return ""
+}
+
+func (m *UserResult) GetResult() UserResultStatus {
+	if m != nil {
+		return m.Result
+	}
+	return UserResultStatus_USER_RESULT_NONE
+}
+
+func (m *UserResult) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *UserResult) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+type ApprovalSubmission struct {
+	Status SubmissionStatus `protobuf:"varint,1,opt,name=status,proto3,enum=permit.SubmissionStatus" json:"status,omitempty"`
+	// approvalID is the identifier the approval service gave the approval request.
+	ApprovalID string `protobuf:"bytes,2,opt,name=approvalID,proto3" json:"approvalID,omitempty"`
+	// message is the error of an approval request that failed to submit.
+	Message              string   `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
+func (m *ApprovalSubmission) Reset()         { *m = ApprovalSubmission{} }
+func (m *ApprovalSubmission) String() string { return proto.CompactTextString(m) }
+func (*ApprovalSubmission) ProtoMessage()    {}
+func (*ApprovalSubmission) Descriptor() ([]byte, []int) {
+	return fileDescriptor_727fd833651e2ed7, []int{4}
+}
+
+func (m *ApprovalSubmission) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ApprovalSubmission.Unmarshal(m, b)
+}
+func (m *ApprovalSubmission) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ApprovalSubmission.Marshal(b, m, deterministic)
+}
+func (m *ApprovalSubmission) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ApprovalSubmission.Merge(m, src)
+}
+func (m *ApprovalSubmission) XXX_Size() int {
+	return xxx_messageInfo_ApprovalSubmission.Size(m)
+}
+func (m *ApprovalSubmission) XXX_DiscardUnknown() {
+	xxx_messageInfo_ApprovalSubmission.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ApprovalSubmission proto.InternalMessageInfo
+
+func (m *ApprovalSubmission) GetStatus() SubmissionStatus {
+	if m != nil {
+		return m.Status
+	}
+	return SubmissionStatus_SUBMISSION_NONE
+}
+
+func (m *ApprovalSubmission) GetApprovalID() string {
+	if m != nil {
+		return m.ApprovalID
+	}
+	return ""
+}
+
+func (m *ApprovalSubmission) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+type UpdatePermitStatusRequest struct {
+	ReqID string `protobuf:"bytes,1,opt,name=reqID,proto3" json:"reqID,omitempty"`
+	// status is the approver's decision, either approved or denied.
+	Status string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Actor  string `protobuf:"bytes,3,opt,name=actor,proto3" json:"actor,omitempty"`
+	Reason string `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	// decisions update the permits of each of the users individually instead of
+	// updating every permit of the request to status.
+	Decisions            []*UserDecision `protobuf:"bytes,5,rep,name=decisions,proto3" json:"decisions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
 func (m *UpdatePermitStatusRequest) Reset()         { *m = UpdatePermitStatusRequest{} }
 func (m *UpdatePermitStatusRequest) String() string { return proto.CompactTextString(m) }
 func (*UpdatePermitStatusRequest) ProtoMessage()    {}
 func (*UpdatePermitStatusRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_727fd833651e2ed7, []int{3}
+	return fileDescriptor_727fd833651e2ed7, []int{5}
 }
 
 func (m *UpdatePermitStatusRequest) XXX_Unmarshal(b []byte) error {
@@ -236,6 +569,82 @@ func (m *UpdatePermitStatusRequest) GetStatus() string {
 	return ""
 }
 
+func (m *UpdatePermitStatusRequest) GetActor() string {
+	if m != nil {
+		return m.Actor
+	}
+	return ""
+}
+
+func (m *UpdatePermitStatusRequest) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *UpdatePermitStatusRequest) GetDecisions() []*UserDecision {
+	if m != nil {
+		return m.Decisions
+	}
+	return nil
+}
+
+type UserDecision struct {
+	UserID               string   `protobuf:"bytes,1,opt,name=userID,proto3" json:"userID,omitempty"`
+	Status               string   `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Reason               string   `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UserDecision) Reset()         { *m = UserDecision{} }
+func (m *UserDecision) String() string { return proto.CompactTextString(m) }
+func (*UserDecision) ProtoMessage()    {}
+func (*UserDecision) Descriptor() ([]byte, []int) {
+	return fileDescriptor_727fd833651e2ed7, []int{6}
+}
+
+func (m *UserDecision) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UserDecision.Unmarshal(m, b)
+}
+func (m *UserDecision) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UserDecision.Marshal(b, m, deterministic)
+}
+func (m *UserDecision) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UserDecision.Merge(m, src)
+}
+func (m *UserDecision) XXX_Size() int {
+	return xxx_messageInfo_UserDecision.Size(m)
+}
+func (m *UserDecision) XXX_DiscardUnknown() {
+	xxx_messageInfo_UserDecision.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UserDecision proto.InternalMessageInfo
+
+func (m *UserDecision) GetUserID() string {
+	if m != nil {
+		return m.UserID
+	}
+	return ""
+}
+
+func (m *UserDecision) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *UserDecision) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
 type UpdatePermitStatusResponse struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -246,7 +655,7 @@ func (m *UpdatePermitStatusResponse) Reset()         { *m = UpdatePermitStatusRe
 func (m *UpdatePermitStatusResponse) String() string { return proto.CompactTextString(m) }
 func (*UpdatePermitStatusResponse) ProtoMessage()    {}
 func (*UpdatePermitStatusResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_727fd833651e2ed7, []int{4}
+	return fileDescriptor_727fd833651e2ed7, []int{7}
 }
 
 func (m *UpdatePermitStatusResponse) XXX_Unmarshal(b []byte) error {
@@ -267,75 +676,247 @@ func (m *UpdatePermitStatusResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_UpdatePermitStatusResponse proto.InternalMessageInfo
 
-type GetPermitByFileIDRequest struct {
+type RevokePermitRequest struct {
 	FileID               string   `protobuf:"bytes,1,opt,name=fileID,proto3" json:"fileID,omitempty"`
+	UserID               string   `protobuf:"bytes,2,opt,name=userID,proto3" json:"userID,omitempty"`
+	Actor                string   `protobuf:"bytes,3,opt,name=actor,proto3" json:"actor,omitempty"`
+	Reason               string   `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *GetPermitByFileIDRequest) Reset()         { *m = GetPermitByFileIDRequest{} }
-func (m *GetPermitByFileIDRequest) String() string { return proto.CompactTextString(m) }
-func (*GetPermitByFileIDRequest) ProtoMessage()    {}
-func (*GetPermitByFileIDRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_727fd833651e2ed7, []int{5}
+func (m *RevokePermitRequest) Reset()         { *m = RevokePermitRequest{} }
+func (m *RevokePermitRequest) String() string { return proto.CompactTextString(m) }
+func (*RevokePermitRequest) ProtoMessage()    {}
+func (*RevokePermitRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_727fd833651e2ed7, []int{8}
 }
 
-func (m *GetPermitByFileIDRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetPermitByFileIDRequest.Unmarshal(m, b)
+func (m *RevokePermitRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RevokePermitRequest.Unmarshal(m, b)
 }
-func (m *GetPermitByFileIDRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetPermitByFileIDRequest.Marshal(b, m, deterministic)
+func (m *RevokePermitRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RevokePermitRequest.Marshal(b, m, deterministic)
 }
-func (m *GetPermitByFileIDRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetPermitByFileIDRequest.Merge(m, src)
+func (m *RevokePermitRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RevokePermitRequest.Merge(m, src)
 }
-func (m *GetPermitByFileIDRequest) XXX_Size() int {
-	return xxx_messageInfo_GetPermitByFileIDRequest.Size(m)
+func (m *RevokePermitRequest) XXX_Size() int {
+	return xxx_messageInfo_RevokePermitRequest.Size(m)
 }
-func (m *GetPermitByFileIDRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetPermitByFileIDRequest.DiscardUnknown(m)
+func (m *RevokePermitRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RevokePermitRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetPermitByFileIDRequest proto.InternalMessageInfo
+var xxx_messageInfo_RevokePermitRequest proto.InternalMessageInfo
 
-func (m *GetPermitByFileIDRequest) GetFileID() string {
+func (m *RevokePermitRequest) GetFileID() string {
 	if m != nil {
 		return m.FileID
 	}
 	return ""
 }
 
-type GetPermitByFileIDResponse struct {
-	UserStatus           []*UserStatus `protobuf:"bytes,1,rep,name=userStatus,proto3" json:"userStatus,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
-	XXX_unrecognized     []byte        `json:"-"`
-	XXX_sizecache        int32         `json:"-"`
+func (m *RevokePermitRequest) GetUserID() string {
+	if m != nil {
+		return m.UserID
+	}
+	return ""
 }
 
-func (m *GetPermitByFileIDResponse) Reset()         { *m = GetPermitByFileIDResponse{} }
-func (m *GetPermitByFileIDResponse) String() string { return proto.CompactTextString(m) }
-func (*GetPermitByFileIDResponse) ProtoMessage()    {}
-func (*GetPermitByFileIDResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_727fd833651e2ed7, []int{6}
+func (m *RevokePermitRequest) GetActor() string {
+	if m != nil {
+		return m.Actor
+	}
+	return ""
 }
 
-func (m *GetPermitByFileIDResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetPermitByFileIDResponse.Unmarshal(m, b)
+func (m *RevokePermitRequest) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
 }
-func (m *GetPermitByFileIDResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetPermitByFileIDResponse.Marshal(b, m, deterministic)
+
+type RevokePermitResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
-func (m *GetPermitByFileIDResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetPermitByFileIDResponse.Merge(m, src)
+
+func (m *RevokePermitResponse) Reset()         { *m = RevokePermitResponse{} }
+func (m *RevokePermitResponse) String() string { return proto.CompactTextString(m) }
+func (*RevokePermitResponse) ProtoMessage()    {}
+func (*RevokePermitResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_727fd833651e2ed7, []int{9}
 }
-func (m *GetPermitByFileIDResponse) XXX_Size() int {
-	return xxx_messageInfo_GetPermitByFileIDResponse.Size(m)
+
+func (m *RevokePermitResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RevokePermitResponse.Unmarshal(m, b)
 }
-func (m *GetPermitByFileIDResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetPermitByFileIDResponse.DiscardUnknown(m)
+func (m *RevokePermitResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RevokePermitResponse.Marshal(b, m, deterministic)
 }
-
+func (m *RevokePermitResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RevokePermitResponse.Merge(m, src)
+}
+func (m *RevokePermitResponse) XXX_Size() int {
+	return xxx_messageInfo_RevokePermitResponse.Size(m)
+}
+func (m *RevokePermitResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RevokePermitResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RevokePermitResponse proto.InternalMessageInfo
+
+type CancelPermitRequestRequest struct {
+	ReqID                string   `protobuf:"bytes,1,opt,name=reqID,proto3" json:"reqID,omitempty"`
+	Actor                string   `protobuf:"bytes,2,opt,name=actor,proto3" json:"actor,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CancelPermitRequestRequest) Reset()         { *m = CancelPermitRequestRequest{} }
+func (m *CancelPermitRequestRequest) String() string { return proto.CompactTextString(m) }
+func (*CancelPermitRequestRequest) ProtoMessage()    {}
+func (*CancelPermitRequestRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_727fd833651e2ed7, []int{10}
+}
+
+func (m *CancelPermitRequestRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CancelPermitRequestRequest.Unmarshal(m, b)
+}
+func (m *CancelPermitRequestRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CancelPermitRequestRequest.Marshal(b, m, deterministic)
+}
+func (m *CancelPermitRequestRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CancelPermitRequestRequest.Merge(m, src)
+}
+func (m *CancelPermitRequestRequest) XXX_Size() int {
+	return xxx_messageInfo_CancelPermitRequestRequest.Size(m)
+}
+func (m *CancelPermitRequestRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CancelPermitRequestRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CancelPermitRequestRequest proto.InternalMessageInfo
+
+func (m *CancelPermitRequestRequest) GetReqID() string {
+	if m != nil {
+		return m.ReqID
+	}
+	return ""
+}
+
+func (m *CancelPermitRequestRequest) GetActor() string {
+	if m != nil {
+		return m.Actor
+	}
+	return ""
+}
+
+type CancelPermitRequestResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CancelPermitRequestResponse) Reset()         { *m = CancelPermitRequestResponse{} }
+func (m *CancelPermitRequestResponse) String() string { return proto.CompactTextString(m) }
+func (*CancelPermitRequestResponse) ProtoMessage()    {}
+func (*CancelPermitRequestResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_727fd833651e2ed7, []int{11}
+}
+
+func (m *CancelPermitRequestResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CancelPermitRequestResponse.Unmarshal(m, b)
+}
+func (m *CancelPermitRequestResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CancelPermitRequestResponse.Marshal(b, m, deterministic)
+}
+func (m *CancelPermitRequestResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CancelPermitRequestResponse.Merge(m, src)
+}
+func (m *CancelPermitRequestResponse) XXX_Size() int {
+	return xxx_messageInfo_CancelPermitRequestResponse.Size(m)
+}
+func (m *CancelPermitRequestResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CancelPermitRequestResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CancelPermitRequestResponse proto.InternalMessageInfo
+
+type GetPermitByFileIDRequest struct {
+	FileID               string   `protobuf:"bytes,1,opt,name=fileID,proto3" json:"fileID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetPermitByFileIDRequest) Reset()         { *m = GetPermitByFileIDRequest{} }
+func (m *GetPermitByFileIDRequest) String() string { return proto.CompactTextString(m) }
+func (*GetPermitByFileIDRequest) ProtoMessage()    {}
+func (*GetPermitByFileIDRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_727fd833651e2ed7, []int{12}
+}
+
+func (m *GetPermitByFileIDRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetPermitByFileIDRequest.Unmarshal(m, b)
+}
+func (m *GetPermitByFileIDRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetPermitByFileIDRequest.Marshal(b, m, deterministic)
+}
+func (m *GetPermitByFileIDRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetPermitByFileIDRequest.Merge(m, src)
+}
+func (m *GetPermitByFileIDRequest) XXX_Size() int {
+	return xxx_messageInfo_GetPermitByFileIDRequest.Size(m)
+}
+func (m *GetPermitByFileIDRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetPermitByFileIDRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetPermitByFileIDRequest proto.InternalMessageInfo
+
+func (m *GetPermitByFileIDRequest) GetFileID() string {
+	if m != nil {
+		return m.FileID
+	}
+	return ""
+}
+
+type GetPermitByFileIDResponse struct {
+	UserStatus           []*UserStatus `protobuf:"bytes,1,rep,name=userStatus,proto3" json:"userStatus,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *GetPermitByFileIDResponse) Reset()         { *m = GetPermitByFileIDResponse{} }
+func (m *GetPermitByFileIDResponse) String() string { return proto.CompactTextString(m) }
+func (*GetPermitByFileIDResponse) ProtoMessage()    {}
+func (*GetPermitByFileIDResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_727fd833651e2ed7, []int{13}
+}
+
+func (m *GetPermitByFileIDResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetPermitByFileIDResponse.Unmarshal(m, b)
+}
+func (m *GetPermitByFileIDResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetPermitByFileIDResponse.Marshal(b, m, deterministic)
+}
+func (m *GetPermitByFileIDResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetPermitByFileIDResponse.Merge(m, src)
+}
+func (m *GetPermitByFileIDResponse) XXX_Size() int {
+	return xxx_messageInfo_GetPermitByFileIDResponse.Size(m)
+}
+func (m *GetPermitByFileIDResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetPermitByFileIDResponse.DiscardUnknown(m)
+}
+
 var xxx_messageInfo_GetPermitByFileIDResponse proto.InternalMessageInfo
 
 func (m *GetPermitByFileIDResponse) GetUserStatus() []*UserStatus {
@@ -357,7 +938,7 @@ func (m *HasPermitRequest) Reset()         { *m = HasPermitRequest{} }
 func (m *HasPermitRequest) String() string { return proto.CompactTextString(m) }
 func (*HasPermitRequest) ProtoMessage()    {}
 func (*HasPermitRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_727fd833651e2ed7, []int{7}
+	return fileDescriptor_727fd833651e2ed7, []int{14}
 }
 
 func (m *HasPermitRequest) XXX_Unmarshal(b []byte) error {
@@ -394,6 +975,9 @@ func (m *HasPermitRequest) GetUserID() string {
 
 type HasPermitResponse struct {
 	HasPermit            bool     `protobuf:"varint,1,opt,name=hasPermit,proto3" json:"hasPermit,omitempty"`
+	Decision             Decision `protobuf:"varint,2,opt,name=decision,proto3,enum=permit.Decision" json:"decision,omitempty"`
+	ReqID                string   `protobuf:"bytes,3,opt,name=reqID,proto3" json:"reqID,omitempty"`
+	Status               string   `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -403,7 +987,7 @@ func (m *HasPermitResponse) Reset()         { *m = HasPermitResponse{} }
 func (m *HasPermitResponse) String() string { return proto.CompactTextString(m) }
 func (*HasPermitResponse) ProtoMessage()    {}
 func (*HasPermitResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_727fd833651e2ed7, []int{8}
+	return fileDescriptor_727fd833651e2ed7, []int{15}
 }
 
 func (m *HasPermitResponse) XXX_Unmarshal(b []byte) error {
@@ -431,164 +1015,1138 @@ func (m *HasPermitResponse) GetHasPermit() bool {
 	return false
 }
 
-type UserStatus struct {
-	UserId               string   `protobuf:"bytes,1,opt,name=userId,proto3" json:"userId,omitempty"`
-	Status               string   `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+func (m *HasPermitResponse) GetDecision() Decision {
+	if m != nil {
+		return m.Decision
+	}
+	return Decision_DECISION_NONE
+}
+
+func (m *HasPermitResponse) GetReqID() string {
+	if m != nil {
+		return m.ReqID
+	}
+	return ""
+}
+
+func (m *HasPermitResponse) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+type HasPermitBatchRequest struct {
+	Permits              []*HasPermitRequest `protobuf:"bytes,1,rep,name=permits,proto3" json:"permits,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *HasPermitBatchRequest) Reset()         { *m = HasPermitBatchRequest{} }
+func (m *HasPermitBatchRequest) String() string { return proto.CompactTextString(m) }
+func (*HasPermitBatchRequest) ProtoMessage()    {}
+func (*HasPermitBatchRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_727fd833651e2ed7, []int{16}
+}
+
+func (m *HasPermitBatchRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HasPermitBatchRequest.Unmarshal(m, b)
+}
+func (m *HasPermitBatchRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HasPermitBatchRequest.Marshal(b, m, deterministic)
+}
+func (m *HasPermitBatchRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HasPermitBatchRequest.Merge(m, src)
+}
+func (m *HasPermitBatchRequest) XXX_Size() int {
+	return xxx_messageInfo_HasPermitBatchRequest.Size(m)
+}
+func (m *HasPermitBatchRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_HasPermitBatchRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HasPermitBatchRequest proto.InternalMessageInfo
+
+func (m *HasPermitBatchRequest) GetPermits() []*HasPermitRequest {
+	if m != nil {
+		return m.Permits
+	}
+	return nil
+}
+
+type HasPermitBatchResponse struct {
+	// results holds the result of each of the request's permits, in the same order.
+	Results              []*HasPermitResponse `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *HasPermitBatchResponse) Reset()         { *m = HasPermitBatchResponse{} }
+func (m *HasPermitBatchResponse) String() string { return proto.CompactTextString(m) }
+func (*HasPermitBatchResponse) ProtoMessage()    {}
+func (*HasPermitBatchResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_727fd833651e2ed7, []int{17}
+}
+
+func (m *HasPermitBatchResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HasPermitBatchResponse.Unmarshal(m, b)
+}
+func (m *HasPermitBatchResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HasPermitBatchResponse.Marshal(b, m, deterministic)
+}
+func (m *HasPermitBatchResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HasPermitBatchResponse.Merge(m, src)
+}
+func (m *HasPermitBatchResponse) XXX_Size() int {
+	return xxx_messageInfo_HasPermitBatchResponse.Size(m)
+}
+func (m *HasPermitBatchResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_HasPermitBatchResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HasPermitBatchResponse proto.InternalMessageInfo
+
+func (m *HasPermitBatchResponse) GetResults() []*HasPermitResponse {
+	if m != nil {
+		return m.Results
+	}
+	return nil
+}
+
+type GetPermitRequestRequest struct {
+	ReqID                string   `protobuf:"bytes,1,opt,name=reqID,proto3" json:"reqID,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *UserStatus) Reset()         { *m = UserStatus{} }
-func (m *UserStatus) String() string { return proto.CompactTextString(m) }
-func (*UserStatus) ProtoMessage()    {}
-func (*UserStatus) Descriptor() ([]byte, []int) {
-	return fileDescriptor_727fd833651e2ed7, []int{9}
+func (m *GetPermitRequestRequest) Reset()         { *m = GetPermitRequestRequest{} }
+func (m *GetPermitRequestRequest) String() string { return proto.CompactTextString(m) }
+func (*GetPermitRequestRequest) ProtoMessage()    {}
+func (*GetPermitRequestRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_727fd833651e2ed7, []int{18}
 }
 
-func (m *UserStatus) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_UserStatus.Unmarshal(m, b)
+func (m *GetPermitRequestRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetPermitRequestRequest.Unmarshal(m, b)
 }
-func (m *UserStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_UserStatus.Marshal(b, m, deterministic)
+func (m *GetPermitRequestRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetPermitRequestRequest.Marshal(b, m, deterministic)
 }
-func (m *UserStatus) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_UserStatus.Merge(m, src)
+func (m *GetPermitRequestRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetPermitRequestRequest.Merge(m, src)
 }
-func (m *UserStatus) XXX_Size() int {
-	return xxx_messageInfo_UserStatus.Size(m)
+func (m *GetPermitRequestRequest) XXX_Size() int {
+	return xxx_messageInfo_GetPermitRequestRequest.Size(m)
 }
-func (m *UserStatus) XXX_DiscardUnknown() {
-	xxx_messageInfo_UserStatus.DiscardUnknown(m)
+func (m *GetPermitRequestRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetPermitRequestRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetPermitRequestRequest proto.InternalMessageInfo
+
+func (m *GetPermitRequestRequest) GetReqID() string {
+	if m != nil {
+		return m.ReqID
+	}
+	return ""
+}
+
+type GetPermitRequestResponse struct {
+	Request              *PermitRequestObject `protobuf:"bytes,1,opt,name=request,proto3" json:"request,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *GetPermitRequestResponse) Reset()         { *m = GetPermitRequestResponse{} }
+func (m *GetPermitRequestResponse) String() string { return proto.CompactTextString(m) }
+func (*GetPermitRequestResponse) ProtoMessage()    {}
+func (*GetPermitRequestResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_727fd833651e2ed7, []int{19}
+}
+
+func (m *GetPermitRequestResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetPermitRequestResponse.Unmarshal(m, b)
+}
+func (m *GetPermitRequestResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetPermitRequestResponse.Marshal(b, m, deterministic)
+}
+func (m *GetPermitRequestResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetPermitRequestResponse.Merge(m, src)
+}
+func (m *GetPermitRequestResponse) XXX_Size() int {
+	return xxx_messageInfo_GetPermitRequestResponse.Size(m)
+}
+func (m *GetPermitRequestResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetPermitRequestResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetPermitRequestResponse proto.InternalMessageInfo
+
+func (m *GetPermitRequestResponse) GetRequest() *PermitRequestObject {
+	if m != nil {
+		return m.Request
+	}
+	return nil
+}
+
+type ListPermitsByUserRequest struct {
+	UserID string `protobuf:"bytes,1,opt,name=userID,proto3" json:"userID,omitempty"`
+	// statuses filters the permits by their status, all permits are listed if it's empty.
+	Statuses []string `protobuf:"bytes,2,rep,name=statuses,proto3" json:"statuses,omitempty"`
+	PageSize int32    `protobuf:"varint,3,opt,name=pageSize,proto3" json:"pageSize,omitempty"`
+	// pageToken is the nextPageToken of the previous page, the first page is listed if it's empty.
+	PageToken            string   `protobuf:"bytes,4,opt,name=pageToken,proto3" json:"pageToken,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListPermitsByUserRequest) Reset()         { *m = ListPermitsByUserRequest{} }
+func (m *ListPermitsByUserRequest) String() string { return proto.CompactTextString(m) }
+func (*ListPermitsByUserRequest) ProtoMessage()    {}
+func (*ListPermitsByUserRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_727fd833651e2ed7, []int{20}
+}
+
+func (m *ListPermitsByUserRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListPermitsByUserRequest.Unmarshal(m, b)
+}
+func (m *ListPermitsByUserRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListPermitsByUserRequest.Marshal(b, m, deterministic)
+}
+func (m *ListPermitsByUserRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListPermitsByUserRequest.Merge(m, src)
+}
+func (m *ListPermitsByUserRequest) XXX_Size() int {
+	return xxx_messageInfo_ListPermitsByUserRequest.Size(m)
+}
+func (m *ListPermitsByUserRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListPermitsByUserRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListPermitsByUserRequest proto.InternalMessageInfo
+
+func (m *ListPermitsByUserRequest) GetUserID() string {
+	if m != nil {
+		return m.UserID
+	}
+	return ""
+}
+
+func (m *ListPermitsByUserRequest) GetStatuses() []string {
+	if m != nil {
+		return m.Statuses
+	}
+	return nil
+}
+
+func (m *ListPermitsByUserRequest) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *ListPermitsByUserRequest) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+type ListPermitsByUserResponse struct {
+	Permits []*PermitObject `protobuf:"bytes,1,rep,name=permits,proto3" json:"permits,omitempty"`
+	// nextPageToken is empty if there are no more pages.
+	NextPageToken        string   `protobuf:"bytes,2,opt,name=nextPageToken,proto3" json:"nextPageToken,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListPermitsByUserResponse) Reset()         { *m = ListPermitsByUserResponse{} }
+func (m *ListPermitsByUserResponse) String() string { return proto.CompactTextString(m) }
+func (*ListPermitsByUserResponse) ProtoMessage()    {}
+func (*ListPermitsByUserResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_727fd833651e2ed7, []int{21}
+}
+
+func (m *ListPermitsByUserResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListPermitsByUserResponse.Unmarshal(m, b)
+}
+func (m *ListPermitsByUserResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListPermitsByUserResponse.Marshal(b, m, deterministic)
+}
+func (m *ListPermitsByUserResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListPermitsByUserResponse.Merge(m, src)
+}
+func (m *ListPermitsByUserResponse) XXX_Size() int {
+	return xxx_messageInfo_ListPermitsByUserResponse.Size(m)
+}
+func (m *ListPermitsByUserResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListPermitsByUserResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListPermitsByUserResponse proto.InternalMessageInfo
+
+func (m *ListPermitsByUserResponse) GetPermits() []*PermitObject {
+	if m != nil {
+		return m.Permits
+	}
+	return nil
+}
+
+func (m *ListPermitsByUserResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+type ListPermitRequestsBySharerRequest struct {
+	SharerID string `protobuf:"bytes,1,opt,name=sharerID,proto3" json:"sharerID,omitempty"`
+	// statuses filters the requests by the status of their users' permits, a request is listed
+	// if any of its users has one of statuses. All requests are listed if it's empty.
+	Statuses []string `protobuf:"bytes,2,rep,name=statuses,proto3" json:"statuses,omitempty"`
+	PageSize int32    `protobuf:"varint,3,opt,name=pageSize,proto3" json:"pageSize,omitempty"`
+	// pageToken is the nextPageToken of the previous page, the first page is listed if it's empty.
+	PageToken            string   `protobuf:"bytes,4,opt,name=pageToken,proto3" json:"pageToken,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListPermitRequestsBySharerRequest) Reset()         { *m = ListPermitRequestsBySharerRequest{} }
+func (m *ListPermitRequestsBySharerRequest) String() string { return proto.CompactTextString(m) }
+func (*ListPermitRequestsBySharerRequest) ProtoMessage()    {}
+func (*ListPermitRequestsBySharerRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_727fd833651e2ed7, []int{22}
+}
+
+func (m *ListPermitRequestsBySharerRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListPermitRequestsBySharerRequest.Unmarshal(m, b)
+}
+func (m *ListPermitRequestsBySharerRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListPermitRequestsBySharerRequest.Marshal(b, m, deterministic)
+}
+func (m *ListPermitRequestsBySharerRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListPermitRequestsBySharerRequest.Merge(m, src)
+}
+func (m *ListPermitRequestsBySharerRequest) XXX_Size() int {
+	return xxx_messageInfo_ListPermitRequestsBySharerRequest.Size(m)
+}
+func (m *ListPermitRequestsBySharerRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListPermitRequestsBySharerRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListPermitRequestsBySharerRequest proto.InternalMessageInfo
+
+func (m *ListPermitRequestsBySharerRequest) GetSharerID() string {
+	if m != nil {
+		return m.SharerID
+	}
+	return ""
+}
+
+func (m *ListPermitRequestsBySharerRequest) GetStatuses() []string {
+	if m != nil {
+		return m.Statuses
+	}
+	return nil
+}
+
+func (m *ListPermitRequestsBySharerRequest) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *ListPermitRequestsBySharerRequest) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+type ListPermitRequestsBySharerResponse struct {
+	Requests []*PermitRequestObject `protobuf:"bytes,1,rep,name=requests,proto3" json:"requests,omitempty"`
+	// nextPageToken is empty if there are no more pages.
+	NextPageToken        string   `protobuf:"bytes,2,opt,name=nextPageToken,proto3" json:"nextPageToken,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListPermitRequestsBySharerResponse) Reset()         { *m = ListPermitRequestsBySharerResponse{} }
+func (m *ListPermitRequestsBySharerResponse) String() string { return proto.CompactTextString(m) }
+func (*ListPermitRequestsBySharerResponse) ProtoMessage()    {}
+func (*ListPermitRequestsBySharerResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_727fd833651e2ed7, []int{23}
+}
+
+func (m *ListPermitRequestsBySharerResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListPermitRequestsBySharerResponse.Unmarshal(m, b)
+}
+func (m *ListPermitRequestsBySharerResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListPermitRequestsBySharerResponse.Marshal(b, m, deterministic)
+}
+func (m *ListPermitRequestsBySharerResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListPermitRequestsBySharerResponse.Merge(m, src)
+}
+func (m *ListPermitRequestsBySharerResponse) XXX_Size() int {
+	return xxx_messageInfo_ListPermitRequestsBySharerResponse.Size(m)
+}
+func (m *ListPermitRequestsBySharerResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListPermitRequestsBySharerResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListPermitRequestsBySharerResponse proto.InternalMessageInfo
+
+func (m *ListPermitRequestsBySharerResponse) GetRequests() []*PermitRequestObject {
+	if m != nil {
+		return m.Requests
+	}
+	return nil
+}
+
+func (m *ListPermitRequestsBySharerResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+type ListPendingApprovalsRequest struct {
+	ApproverID string `protobuf:"bytes,1,opt,name=approverID,proto3" json:"approverID,omitempty"`
+	PageSize   int32  `protobuf:"varint,2,opt,name=pageSize,proto3" json:"pageSize,omitempty"`
+	// pageToken is the nextPageToken of the previous page, the first page is listed if it's empty.
+	PageToken            string   `protobuf:"bytes,3,opt,name=pageToken,proto3" json:"pageToken,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListPendingApprovalsRequest) Reset()         { *m = ListPendingApprovalsRequest{} }
+func (m *ListPendingApprovalsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListPendingApprovalsRequest) ProtoMessage()    {}
+func (*ListPendingApprovalsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_727fd833651e2ed7, []int{24}
+}
+
+func (m *ListPendingApprovalsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListPendingApprovalsRequest.Unmarshal(m, b)
+}
+func (m *ListPendingApprovalsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListPendingApprovalsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListPendingApprovalsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListPendingApprovalsRequest.Merge(m, src)
+}
+func (m *ListPendingApprovalsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListPendingApprovalsRequest.Size(m)
+}
+func (m *ListPendingApprovalsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListPendingApprovalsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListPendingApprovalsRequest proto.InternalMessageInfo
+
+func (m *ListPendingApprovalsRequest) GetApproverID() string {
+	if m != nil {
+		return m.ApproverID
+	}
+	return ""
+}
+
+func (m *ListPendingApprovalsRequest) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *ListPendingApprovalsRequest) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+type ListPendingApprovalsResponse struct {
+	Requests []*PermitRequestObject `protobuf:"bytes,1,rep,name=requests,proto3" json:"requests,omitempty"`
+	// nextPageToken is empty if there are no more pages.
+	NextPageToken        string   `protobuf:"bytes,2,opt,name=nextPageToken,proto3" json:"nextPageToken,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListPendingApprovalsResponse) Reset()         { *m = ListPendingApprovalsResponse{} }
+func (m *ListPendingApprovalsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListPendingApprovalsResponse) ProtoMessage()    {}
+func (*ListPendingApprovalsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_727fd833651e2ed7, []int{25}
+}
+
+func (m *ListPendingApprovalsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListPendingApprovalsResponse.Unmarshal(m, b)
+}
+func (m *ListPendingApprovalsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListPendingApprovalsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListPendingApprovalsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListPendingApprovalsResponse.Merge(m, src)
+}
+func (m *ListPendingApprovalsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListPendingApprovalsResponse.Size(m)
+}
+func (m *ListPendingApprovalsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListPendingApprovalsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListPendingApprovalsResponse proto.InternalMessageInfo
+
+func (m *ListPendingApprovalsResponse) GetRequests() []*PermitRequestObject {
+	if m != nil {
+		return m.Requests
+	}
+	return nil
+}
+
+func (m *ListPendingApprovalsResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+// WatchPermitsRequest filters the watched permits by any of fileID, userID and reqID,
+// at least one of them is required.
+type WatchPermitsRequest struct {
+	FileID               string   `protobuf:"bytes,1,opt,name=fileID,proto3" json:"fileID,omitempty"`
+	UserID               string   `protobuf:"bytes,2,opt,name=userID,proto3" json:"userID,omitempty"`
+	ReqID                string   `protobuf:"bytes,3,opt,name=reqID,proto3" json:"reqID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WatchPermitsRequest) Reset()         { *m = WatchPermitsRequest{} }
+func (m *WatchPermitsRequest) String() string { return proto.CompactTextString(m) }
+func (*WatchPermitsRequest) ProtoMessage()    {}
+func (*WatchPermitsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_727fd833651e2ed7, []int{26}
+}
+
+func (m *WatchPermitsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WatchPermitsRequest.Unmarshal(m, b)
+}
+func (m *WatchPermitsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WatchPermitsRequest.Marshal(b, m, deterministic)
+}
+func (m *WatchPermitsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchPermitsRequest.Merge(m, src)
+}
+func (m *WatchPermitsRequest) XXX_Size() int {
+	return xxx_messageInfo_WatchPermitsRequest.Size(m)
+}
+func (m *WatchPermitsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchPermitsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchPermitsRequest proto.InternalMessageInfo
+
+func (m *WatchPermitsRequest) GetFileID() string {
+	if m != nil {
+		return m.FileID
+	}
+	return ""
+}
+
+func (m *WatchPermitsRequest) GetUserID() string {
+	if m != nil {
+		return m.UserID
+	}
+	return ""
+}
+
+func (m *WatchPermitsRequest) GetReqID() string {
+	if m != nil {
+		return m.ReqID
+	}
+	return ""
+}
+
+type WatchPermitsResponse struct {
+	Permit               *PermitObject `protobuf:"bytes,1,opt,name=permit,proto3" json:"permit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *WatchPermitsResponse) Reset()         { *m = WatchPermitsResponse{} }
+func (m *WatchPermitsResponse) String() string { return proto.CompactTextString(m) }
+func (*WatchPermitsResponse) ProtoMessage()    {}
+func (*WatchPermitsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_727fd833651e2ed7, []int{27}
+}
+
+func (m *WatchPermitsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WatchPermitsResponse.Unmarshal(m, b)
+}
+func (m *WatchPermitsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WatchPermitsResponse.Marshal(b, m, deterministic)
+}
+func (m *WatchPermitsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchPermitsResponse.Merge(m, src)
+}
+func (m *WatchPermitsResponse) XXX_Size() int {
+	return xxx_messageInfo_WatchPermitsResponse.Size(m)
+}
+func (m *WatchPermitsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchPermitsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchPermitsResponse proto.InternalMessageInfo
+
+func (m *WatchPermitsResponse) GetPermit() *PermitObject {
+	if m != nil {
+		return m.Permit
+	}
+	return nil
+}
+
+type UserStatus struct {
+	UserId               string          `protobuf:"bytes,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	Status               string          `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	CreatedAt            int64           `protobuf:"varint,3,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	UpdatedAt            int64           `protobuf:"varint,4,opt,name=updatedAt,proto3" json:"updatedAt,omitempty"`
+	DecidedAt            int64           `protobuf:"varint,5,opt,name=decidedAt,proto3" json:"decidedAt,omitempty"`
+	History              []*StatusChange `protobuf:"bytes,6,rep,name=history,proto3" json:"history,omitempty"`
+	ExpiresAt            int64           `protobuf:"varint,7,opt,name=expiresAt,proto3" json:"expiresAt,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *UserStatus) Reset()         { *m = UserStatus{} }
+func (m *UserStatus) String() string { return proto.CompactTextString(m) }
+func (*UserStatus) ProtoMessage()    {}
+func (*UserStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_727fd833651e2ed7, []int{28}
+}
+
+func (m *UserStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UserStatus.Unmarshal(m, b)
+}
+func (m *UserStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UserStatus.Marshal(b, m, deterministic)
+}
+func (m *UserStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UserStatus.Merge(m, src)
+}
+func (m *UserStatus) XXX_Size() int {
+	return xxx_messageInfo_UserStatus.Size(m)
+}
+func (m *UserStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_UserStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UserStatus proto.InternalMessageInfo
+
+func (m *UserStatus) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+func (m *UserStatus) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *UserStatus) GetCreatedAt() int64 {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return 0
+}
+
+func (m *UserStatus) GetUpdatedAt() int64 {
+	if m != nil {
+		return m.UpdatedAt
+	}
+	return 0
+}
+
+func (m *UserStatus) GetDecidedAt() int64 {
+	if m != nil {
+		return m.DecidedAt
+	}
+	return 0
+}
+
+func (m *UserStatus) GetHistory() []*StatusChange {
+	if m != nil {
+		return m.History
+	}
+	return nil
+}
+
+func (m *UserStatus) GetExpiresAt() int64 {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return 0
+}
+
+// StatusChange is an entry of the status history of a permit.
+type StatusChange struct {
+	Status               string   `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Actor                string   `protobuf:"bytes,2,opt,name=actor,proto3" json:"actor,omitempty"`
+	Reason               string   `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	Time                 int64    `protobuf:"varint,4,opt,name=time,proto3" json:"time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StatusChange) Reset()         { *m = StatusChange{} }
+func (m *StatusChange) String() string { return proto.CompactTextString(m) }
+func (*StatusChange) ProtoMessage()    {}
+func (*StatusChange) Descriptor() ([]byte, []int) {
+	return fileDescriptor_727fd833651e2ed7, []int{29}
+}
+
+func (m *StatusChange) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StatusChange.Unmarshal(m, b)
+}
+func (m *StatusChange) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StatusChange.Marshal(b, m, deterministic)
+}
+func (m *StatusChange) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StatusChange.Merge(m, src)
+}
+func (m *StatusChange) XXX_Size() int {
+	return xxx_messageInfo_StatusChange.Size(m)
+}
+func (m *StatusChange) XXX_DiscardUnknown() {
+	xxx_messageInfo_StatusChange.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StatusChange proto.InternalMessageInfo
+
+func (m *StatusChange) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *StatusChange) GetActor() string {
+	if m != nil {
+		return m.Actor
+	}
+	return ""
+}
+
+func (m *StatusChange) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *StatusChange) GetTime() int64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
+}
+
+type PermitObject struct {
+	ReqID                string          `protobuf:"bytes,1,opt,name=reqID,proto3" json:"reqID,omitempty"`
+	FileID               string          `protobuf:"bytes,2,opt,name=fileID,proto3" json:"fileID,omitempty"`
+	UserID               string          `protobuf:"bytes,3,opt,name=userID,proto3" json:"userID,omitempty"`
+	Status               string          `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	CreatedAt            int64           `protobuf:"varint,5,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	UpdatedAt            int64           `protobuf:"varint,6,opt,name=updatedAt,proto3" json:"updatedAt,omitempty"`
+	DecidedAt            int64           `protobuf:"varint,7,opt,name=decidedAt,proto3" json:"decidedAt,omitempty"`
+	History              []*StatusChange `protobuf:"bytes,8,rep,name=history,proto3" json:"history,omitempty"`
+	ExpiresAt            int64           `protobuf:"varint,9,opt,name=expiresAt,proto3" json:"expiresAt,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *PermitObject) Reset()         { *m = PermitObject{} }
+func (m *PermitObject) String() string { return proto.CompactTextString(m) }
+func (*PermitObject) ProtoMessage()    {}
+func (*PermitObject) Descriptor() ([]byte, []int) {
+	return fileDescriptor_727fd833651e2ed7, []int{30}
+}
+
+func (m *PermitObject) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PermitObject.Unmarshal(m, b)
+}
+func (m *PermitObject) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PermitObject.Marshal(b, m, deterministic)
+}
+func (m *PermitObject) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PermitObject.Merge(m, src)
+}
+func (m *PermitObject) XXX_Size() int {
+	return xxx_messageInfo_PermitObject.Size(m)
+}
+func (m *PermitObject) XXX_DiscardUnknown() {
+	xxx_messageInfo_PermitObject.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PermitObject proto.InternalMessageInfo
+
+func (m *PermitObject) GetReqID() string {
+	if m != nil {
+		return m.ReqID
+	}
+	return ""
+}
+
+func (m *PermitObject) GetFileID() string {
+	if m != nil {
+		return m.FileID
+	}
+	return ""
+}
+
+func (m *PermitObject) GetUserID() string {
+	if m != nil {
+		return m.UserID
+	}
+	return ""
+}
+
+func (m *PermitObject) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *PermitObject) GetCreatedAt() int64 {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return 0
+}
+
+func (m *PermitObject) GetUpdatedAt() int64 {
+	if m != nil {
+		return m.UpdatedAt
+	}
+	return 0
+}
+
+func (m *PermitObject) GetDecidedAt() int64 {
+	if m != nil {
+		return m.DecidedAt
+	}
+	return 0
+}
+
+func (m *PermitObject) GetHistory() []*StatusChange {
+	if m != nil {
+		return m.History
+	}
+	return nil
+}
+
+func (m *PermitObject) GetExpiresAt() int64 {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return 0
+}
+
+type PermitRequestObject struct {
+	ReqID          string        `protobuf:"bytes,1,opt,name=reqID,proto3" json:"reqID,omitempty"`
+	FileID         string        `protobuf:"bytes,2,opt,name=fileID,proto3" json:"fileID,omitempty"`
+	FileName       string        `protobuf:"bytes,3,opt,name=fileName,proto3" json:"fileName,omitempty"`
+	SharerID       string        `protobuf:"bytes,4,opt,name=sharerID,proto3" json:"sharerID,omitempty"`
+	Approvers      []string      `protobuf:"bytes,5,rep,name=approvers,proto3" json:"approvers,omitempty"`
+	Classification string        `protobuf:"bytes,6,opt,name=classification,proto3" json:"classification,omitempty"`
+	Info           string        `protobuf:"bytes,7,opt,name=info,proto3" json:"info,omitempty"`
+	Users          []*User       `protobuf:"bytes,8,rep,name=users,proto3" json:"users,omitempty"`
+	UserStatus     []*UserStatus `protobuf:"bytes,9,rep,name=userStatus,proto3" json:"userStatus,omitempty"`
+	CreatedAt      int64         `protobuf:"varint,10,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	ExpiresAt      int64         `protobuf:"varint,11,opt,name=expiresAt,proto3" json:"expiresAt,omitempty"`
+	// approvalID is the identifier the approval service gave the approval request of the request.
+	ApprovalID           string   `protobuf:"bytes,12,opt,name=approvalID,proto3" json:"approvalID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PermitRequestObject) Reset()         { *m = PermitRequestObject{} }
+func (m *PermitRequestObject) String() string { return proto.CompactTextString(m) }
+func (*PermitRequestObject) ProtoMessage()    {}
+func (*PermitRequestObject) Descriptor() ([]byte, []int) {
+	return fileDescriptor_727fd833651e2ed7, []int{31}
+}
+
+func (m *PermitRequestObject) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PermitRequestObject.Unmarshal(m, b)
+}
+func (m *PermitRequestObject) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PermitRequestObject.Marshal(b, m, deterministic)
+}
+func (m *PermitRequestObject) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PermitRequestObject.Merge(m, src)
+}
+func (m *PermitRequestObject) XXX_Size() int {
+	return xxx_messageInfo_PermitRequestObject.Size(m)
+}
+func (m *PermitRequestObject) XXX_DiscardUnknown() {
+	xxx_messageInfo_PermitRequestObject.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_UserStatus proto.InternalMessageInfo
+var xxx_messageInfo_PermitRequestObject proto.InternalMessageInfo
 
-func (m *UserStatus) GetUserId() string {
+func (m *PermitRequestObject) GetReqID() string {
 	if m != nil {
-		return m.UserId
+		return m.ReqID
 	}
 	return ""
 }
 
-func (m *UserStatus) GetStatus() string {
+func (m *PermitRequestObject) GetFileID() string {
 	if m != nil {
-		return m.Status
+		return m.FileID
 	}
 	return ""
 }
 
-type PermitObject struct {
-	ReqID                string   `protobuf:"bytes,1,opt,name=reqID,proto3" json:"reqID,omitempty"`
-	FileID               string   `protobuf:"bytes,2,opt,name=fileID,proto3" json:"fileID,omitempty"`
-	UserID               string   `protobuf:"bytes,3,opt,name=userID,proto3" json:"userID,omitempty"`
-	Status               string   `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *PermitRequestObject) GetFileName() string {
+	if m != nil {
+		return m.FileName
+	}
+	return ""
 }
 
-func (m *PermitObject) Reset()         { *m = PermitObject{} }
-func (m *PermitObject) String() string { return proto.CompactTextString(m) }
-func (*PermitObject) ProtoMessage()    {}
-func (*PermitObject) Descriptor() ([]byte, []int) {
-	return fileDescriptor_727fd833651e2ed7, []int{10}
+func (m *PermitRequestObject) GetSharerID() string {
+	if m != nil {
+		return m.SharerID
+	}
+	return ""
 }
 
-func (m *PermitObject) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_PermitObject.Unmarshal(m, b)
-}
-func (m *PermitObject) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_PermitObject.Marshal(b, m, deterministic)
-}
-func (m *PermitObject) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_PermitObject.Merge(m, src)
+func (m *PermitRequestObject) GetApprovers() []string {
+	if m != nil {
+		return m.Approvers
+	}
+	return nil
 }
-func (m *PermitObject) XXX_Size() int {
-	return xxx_messageInfo_PermitObject.Size(m)
+
+func (m *PermitRequestObject) GetClassification() string {
+	if m != nil {
+		return m.Classification
+	}
+	return ""
 }
-func (m *PermitObject) XXX_DiscardUnknown() {
-	xxx_messageInfo_PermitObject.DiscardUnknown(m)
+
+func (m *PermitRequestObject) GetInfo() string {
+	if m != nil {
+		return m.Info
+	}
+	return ""
 }
 
-var xxx_messageInfo_PermitObject proto.InternalMessageInfo
+func (m *PermitRequestObject) GetUsers() []*User {
+	if m != nil {
+		return m.Users
+	}
+	return nil
+}
 
-func (m *PermitObject) GetReqID() string {
+func (m *PermitRequestObject) GetUserStatus() []*UserStatus {
 	if m != nil {
-		return m.ReqID
+		return m.UserStatus
 	}
-	return ""
+	return nil
 }
 
-func (m *PermitObject) GetFileID() string {
+func (m *PermitRequestObject) GetCreatedAt() int64 {
 	if m != nil {
-		return m.FileID
+		return m.CreatedAt
 	}
-	return ""
+	return 0
 }
 
-func (m *PermitObject) GetUserID() string {
+func (m *PermitRequestObject) GetExpiresAt() int64 {
 	if m != nil {
-		return m.UserID
+		return m.ExpiresAt
 	}
-	return ""
+	return 0
 }
 
-func (m *PermitObject) GetStatus() string {
+func (m *PermitRequestObject) GetApprovalID() string {
 	if m != nil {
-		return m.Status
+		return m.ApprovalID
 	}
 	return ""
 }
 
 func init() {
+	proto.RegisterEnum("permit.UserResultStatus", UserResultStatus_name, UserResultStatus_value)
+	proto.RegisterEnum("permit.SubmissionStatus", SubmissionStatus_name, SubmissionStatus_value)
+	proto.RegisterEnum("permit.PermitStatus", PermitStatus_name, PermitStatus_value)
+	proto.RegisterEnum("permit.Decision", Decision_name, Decision_value)
 	proto.RegisterType((*CreatePermitRequest)(nil), "permit.CreatePermitRequest")
 	proto.RegisterType((*User)(nil), "permit.User")
 	proto.RegisterType((*CreatePermitResponse)(nil), "permit.CreatePermitResponse")
+	proto.RegisterType((*UserResult)(nil), "permit.UserResult")
+	proto.RegisterType((*ApprovalSubmission)(nil), "permit.ApprovalSubmission")
 	proto.RegisterType((*UpdatePermitStatusRequest)(nil), "permit.UpdatePermitStatusRequest")
+	proto.RegisterType((*UserDecision)(nil), "permit.UserDecision")
 	proto.RegisterType((*UpdatePermitStatusResponse)(nil), "permit.UpdatePermitStatusResponse")
+	proto.RegisterType((*RevokePermitRequest)(nil), "permit.RevokePermitRequest")
+	proto.RegisterType((*RevokePermitResponse)(nil), "permit.RevokePermitResponse")
+	proto.RegisterType((*CancelPermitRequestRequest)(nil), "permit.CancelPermitRequestRequest")
+	proto.RegisterType((*CancelPermitRequestResponse)(nil), "permit.CancelPermitRequestResponse")
 	proto.RegisterType((*GetPermitByFileIDRequest)(nil), "permit.GetPermitByFileIDRequest")
 	proto.RegisterType((*GetPermitByFileIDResponse)(nil), "permit.GetPermitByFileIDResponse")
 	proto.RegisterType((*HasPermitRequest)(nil), "permit.HasPermitRequest")
 	proto.RegisterType((*HasPermitResponse)(nil), "permit.HasPermitResponse")
+	proto.RegisterType((*HasPermitBatchRequest)(nil), "permit.HasPermitBatchRequest")
+	proto.RegisterType((*HasPermitBatchResponse)(nil), "permit.HasPermitBatchResponse")
+	proto.RegisterType((*GetPermitRequestRequest)(nil), "permit.GetPermitRequestRequest")
+	proto.RegisterType((*GetPermitRequestResponse)(nil), "permit.GetPermitRequestResponse")
+	proto.RegisterType((*ListPermitsByUserRequest)(nil), "permit.ListPermitsByUserRequest")
+	proto.RegisterType((*ListPermitsByUserResponse)(nil), "permit.ListPermitsByUserResponse")
+	proto.RegisterType((*ListPermitRequestsBySharerRequest)(nil), "permit.ListPermitRequestsBySharerRequest")
+	proto.RegisterType((*ListPermitRequestsBySharerResponse)(nil), "permit.ListPermitRequestsBySharerResponse")
+	proto.RegisterType((*ListPendingApprovalsRequest)(nil), "permit.ListPendingApprovalsRequest")
+	proto.RegisterType((*ListPendingApprovalsResponse)(nil), "permit.ListPendingApprovalsResponse")
+	proto.RegisterType((*WatchPermitsRequest)(nil), "permit.WatchPermitsRequest")
+	proto.RegisterType((*WatchPermitsResponse)(nil), "permit.WatchPermitsResponse")
 	proto.RegisterType((*UserStatus)(nil), "permit.UserStatus")
+	proto.RegisterType((*StatusChange)(nil), "permit.StatusChange")
 	proto.RegisterType((*PermitObject)(nil), "permit.PermitObject")
+	proto.RegisterType((*PermitRequestObject)(nil), "permit.PermitRequestObject")
 }
 
 func init() { proto.RegisterFile("permit.proto", fileDescriptor_727fd833651e2ed7) }
 
 var fileDescriptor_727fd833651e2ed7 = []byte{
-	// 474 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x54, 0x4d, 0x6f, 0xd3, 0x40,
-	0x10, 0x25, 0x4e, 0x62, 0xea, 0x21, 0xaa, 0xe8, 0x50, 0x45, 0x1b, 0x37, 0x87, 0x74, 0x0f, 0x28,
-	0xa7, 0x4a, 0xa4, 0x57, 0x4e, 0xa1, 0x02, 0x22, 0x24, 0x8a, 0x8c, 0x7a, 0x41, 0x42, 0xc8, 0x4d,
-	0x36, 0xea, 0x22, 0x37, 0x76, 0x77, 0x37, 0x48, 0xfc, 0x4f, 0xfe, 0x01, 0x7f, 0x04, 0xed, 0x67,
-	0x4c, 0x63, 0x07, 0x6e, 0x79, 0x33, 0x3b, 0x6f, 0x3e, 0xde, 0x73, 0x60, 0x50, 0x31, 0x71, 0xcf,
-	0xd5, 0x45, 0x25, 0x4a, 0x55, 0x62, 0x6c, 0x11, 0xfd, 0xdd, 0x81, 0x17, 0x6f, 0x04, 0xcb, 0x15,
-	0xfb, 0x64, 0x02, 0x19, 0x7b, 0xd8, 0x32, 0xa9, 0x70, 0x08, 0xf1, 0x9a, 0x17, 0x6c, 0x71, 0x45,
-	0x3a, 0x93, 0xce, 0x34, 0xc9, 0x1c, 0xc2, 0x14, 0x8e, 0xe4, 0x5d, 0x2e, 0x98, 0x58, 0x5c, 0x91,
-	0xc8, 0x64, 0x02, 0x46, 0x0a, 0xfd, 0xad, 0x64, 0x42, 0x92, 0xee, 0xa4, 0x3b, 0x7d, 0x36, 0x1b,
-	0x5c, 0xb8, 0x8e, 0x37, 0x92, 0x89, 0xcc, 0xa6, 0xf0, 0x25, 0x1c, 0x2f, 0x8b, 0x5c, 0x4a, 0xbe,
-	0xe6, 0xcb, 0x5c, 0xf1, 0x72, 0x43, 0x7a, 0x86, 0xe5, 0x51, 0x14, 0x11, 0x7a, 0x7c, 0xb3, 0x2e,
-	0x49, 0xdf, 0x64, 0xcd, 0x6f, 0x1c, 0x43, 0x92, 0x57, 0x95, 0x28, 0x7f, 0xe8, 0x1e, 0xf1, 0xa4,
-	0x3b, 0x4d, 0xb2, 0x5d, 0x40, 0x4f, 0xa6, 0x67, 0xfc, 0x98, 0xdf, 0x33, 0xf2, 0xd4, 0x4e, 0xe6,
-	0x31, 0xbd, 0x84, 0x9e, 0x1e, 0x02, 0x8f, 0x21, 0xe2, 0x2b, 0xb7, 0x51, 0xc4, 0x57, 0x78, 0x06,
-	0xc9, 0x7a, 0x5b, 0x14, 0xdf, 0x36, 0xba, 0xc8, 0xad, 0xa3, 0x03, 0xa6, 0x68, 0x08, 0xa7, 0x7f,
-	0x5f, 0x46, 0x56, 0xe5, 0x46, 0x32, 0xba, 0x80, 0xd1, 0x4d, 0xb5, 0x0a, 0xf1, 0xcf, 0x2a, 0x57,
-	0x5b, 0xe9, 0xef, 0x76, 0x0a, 0x7d, 0xc1, 0x1e, 0xc2, 0xd9, 0x2c, 0xd0, 0xd7, 0x94, 0xe6, 0x99,
-	0x6b, 0xe2, 0x10, 0x1d, 0x43, 0xda, 0x44, 0xe5, 0x1a, 0xcd, 0x80, 0xbc, 0x63, 0xca, 0xa6, 0xe6,
-	0x3f, 0xdf, 0x1a, 0x01, 0xfe, 0xa1, 0x0f, 0xbd, 0x86, 0x51, 0x43, 0x8d, 0x25, 0xc4, 0x19, 0x80,
-	0x56, 0xc1, 0xb6, 0x21, 0x1d, 0xa3, 0x12, 0xd6, 0x55, 0x72, 0x03, 0xd4, 0x5e, 0xd1, 0x39, 0x3c,
-	0x7f, 0x9f, 0xcb, 0xff, 0x33, 0xc7, 0x10, 0x62, 0x5d, 0x19, 0xac, 0xe1, 0x10, 0x7d, 0x05, 0x27,
-	0x35, 0x0e, 0x37, 0xcc, 0x18, 0x92, 0x3b, 0x1f, 0x34, 0x3c, 0x47, 0xd9, 0x2e, 0x40, 0x5f, 0x03,
-	0xec, 0x06, 0x0a, 0xc4, 0x5e, 0x3b, 0x87, 0x5a, 0xef, 0x5a, 0xc0, 0xc0, 0xf2, 0x5c, 0xdf, 0x7e,
-	0x67, 0xcb, 0x03, 0xaa, 0xb8, 0x35, 0xa2, 0x96, 0x35, 0xba, 0xf5, 0x35, 0x6a, 0xdd, 0x7a, 0xf5,
-	0x6e, 0xb3, 0x5f, 0x11, 0xb8, 0xcf, 0x09, 0x3f, 0xc0, 0xa0, 0xee, 0x19, 0x3c, 0xf3, 0xd7, 0x6d,
-	0xf8, 0xc6, 0xd2, 0x71, 0x73, 0xd2, 0xa9, 0xff, 0x04, 0xbf, 0x02, 0xee, 0xbb, 0x03, 0xcf, 0x83,
-	0x60, 0x6d, 0x26, 0x4c, 0xe9, 0xa1, 0x27, 0x81, 0xfe, 0x0b, 0x9c, 0xec, 0x59, 0x05, 0x27, 0xbe,
-	0xb4, 0xcd, 0x79, 0xe9, 0xf9, 0x81, 0x17, 0x81, 0x7b, 0x0e, 0x49, 0x50, 0x1c, 0x89, 0xaf, 0x78,
-	0x6c, 0xa4, 0x74, 0xd4, 0x90, 0xf1, 0x1c, 0xb7, 0xb1, 0xf9, 0xa7, 0xba, 0xfc, 0x13, 0x00, 0x00,
-	0xff, 0xff, 0x8e, 0x48, 0x39, 0x47, 0xb9, 0x04, 0x00, 0x00,
+	// 1628 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x58, 0x4f, 0x6f, 0xdb, 0xc6,
+	0x12, 0x37, 0x45, 0x49, 0x96, 0xc6, 0x8a, 0x1f, 0xbd, 0x56, 0x1c, 0x9a, 0x56, 0xf2, 0x64, 0x26,
+	0x78, 0xf0, 0x33, 0x82, 0x34, 0x50, 0xd0, 0xf6, 0x2c, 0x4b, 0x4c, 0xa2, 0xda, 0x91, 0x15, 0xd2,
+	0x4a, 0xfa, 0x07, 0x85, 0xcb, 0x48, 0x6b, 0x9b, 0x8d, 0x2c, 0xc9, 0x24, 0x95, 0xc6, 0x05, 0x0a,
+	0x14, 0x28, 0x50, 0xb4, 0x87, 0x5e, 0x8a, 0xf4, 0x23, 0xf4, 0xd4, 0x7b, 0x2f, 0xfd, 0x3a, 0xfd,
+	0x20, 0xc5, 0x2e, 0x97, 0xcb, 0x25, 0x45, 0xaa, 0x0e, 0x82, 0xf6, 0xa6, 0x9d, 0x19, 0xce, 0xfc,
+	0x7e, 0x33, 0xfb, 0x67, 0x46, 0x50, 0x99, 0x62, 0xf7, 0xdc, 0xf1, 0xef, 0x4d, 0xdd, 0x89, 0x3f,
+	0x41, 0xc5, 0x60, 0xa5, 0xff, 0x91, 0x83, 0xf5, 0x96, 0x8b, 0x6d, 0x1f, 0xf7, 0xa8, 0xc0, 0xc4,
+	0x17, 0x33, 0xec, 0xf9, 0x68, 0x03, 0x8a, 0x27, 0xce, 0x08, 0x77, 0xda, 0xaa, 0x54, 0x97, 0x76,
+	0xca, 0x26, 0x5b, 0x21, 0x0d, 0x4a, 0xde, 0x99, 0xed, 0x62, 0xb7, 0xd3, 0x56, 0x73, 0x54, 0xc3,
+	0xd7, 0x48, 0x87, 0xc2, 0xcc, 0xc3, 0xae, 0xa7, 0xca, 0x75, 0x79, 0x67, 0xa5, 0x51, 0xb9, 0xc7,
+	0x22, 0xf6, 0x3d, 0xec, 0x9a, 0x81, 0x0a, 0xfd, 0x0f, 0x56, 0x07, 0x23, 0xdb, 0xf3, 0x9c, 0x13,
+	0x67, 0x60, 0xfb, 0xce, 0x64, 0xac, 0xe6, 0xa9, 0x97, 0x84, 0x14, 0x21, 0xc8, 0x3b, 0xe3, 0x93,
+	0x89, 0x5a, 0xa0, 0x5a, 0xfa, 0x1b, 0xd5, 0xa0, 0x6c, 0x4f, 0xa7, 0xee, 0xe4, 0x15, 0x89, 0x51,
+	0xac, 0xcb, 0x3b, 0x65, 0x33, 0x12, 0x10, 0x64, 0x04, 0x63, 0xd7, 0x3e, 0xc7, 0xea, 0x72, 0x80,
+	0x2c, 0x5c, 0x93, 0x2f, 0xf1, 0xeb, 0xa9, 0xe3, 0x62, 0xaf, 0xe9, 0xab, 0xa5, 0xba, 0xb4, 0x23,
+	0x9b, 0x91, 0x00, 0x29, 0x20, 0xfb, 0xfe, 0x48, 0x2d, 0x53, 0x39, 0xf9, 0x49, 0x50, 0x3a, 0x43,
+	0x7c, 0x3e, 0x9d, 0xf8, 0x78, 0x3c, 0xb8, 0xdc, 0xc7, 0x97, 0x2a, 0x04, 0x28, 0xe3, 0x52, 0xfd,
+	0x01, 0xe4, 0x09, 0x39, 0xb4, 0x0a, 0x39, 0x67, 0xc8, 0x32, 0x95, 0x73, 0x86, 0x68, 0x0b, 0xca,
+	0x27, 0xb3, 0xd1, 0xe8, 0x78, 0x4c, 0xc0, 0xb0, 0x34, 0x11, 0x01, 0x01, 0xa3, 0xff, 0x2c, 0x41,
+	0x35, 0x9e, 0x72, 0x6f, 0x3a, 0x19, 0x7b, 0x18, 0x55, 0xa1, 0xe0, 0xe2, 0x0b, 0x9e, 0xf2, 0x60,
+	0x81, 0xee, 0xc2, 0xb2, 0x8b, 0xbd, 0xd9, 0xc8, 0xf7, 0xd4, 0x1c, 0xcd, 0x2b, 0x8a, 0xe5, 0x95,
+	0xaa, 0xcc, 0xd0, 0x04, 0x7d, 0x00, 0xa5, 0x20, 0x25, 0xf6, 0x48, 0x95, 0xeb, 0xd2, 0xce, 0x4a,
+	0x43, 0x0b, 0xcd, 0x9b, 0x4c, 0x6e, 0xcd, 0x5e, 0x9c, 0x3b, 0x9e, 0xe7, 0x4c, 0xc6, 0x26, 0xb7,
+	0xd5, 0xbf, 0x97, 0x00, 0x22, 0x7f, 0xa4, 0xfc, 0xa4, 0x5e, 0x51, 0xf9, 0x83, 0x15, 0xba, 0x0f,
+	0xc5, 0x20, 0x12, 0x65, 0xb5, 0xda, 0x50, 0xe7, 0xb1, 0x58, 0xbe, 0xed, 0xcf, 0x3c, 0xb3, 0xe8,
+	0x72, 0x4f, 0x1e, 0x95, 0x50, 0x38, 0x65, 0x93, 0xad, 0x88, 0xdc, 0xc5, 0xb6, 0xc7, 0x37, 0x00,
+	0x5b, 0xe9, 0xdf, 0x4a, 0x80, 0xe6, 0x91, 0x92, 0xc0, 0xcc, 0x8d, 0x14, 0x0f, 0x1c, 0xd9, 0x84,
+	0x81, 0x59, 0x80, 0x5b, 0x00, 0x21, 0x3b, 0xbe, 0x57, 0x05, 0x09, 0x52, 0x61, 0xf9, 0x1c, 0x7b,
+	0x9e, 0x7d, 0x8a, 0x19, 0xb2, 0x70, 0xa9, 0xff, 0x26, 0xc1, 0x66, 0x7f, 0x3a, 0xe4, 0x05, 0x62,
+	0x8e, 0xd9, 0xc9, 0x48, 0xaf, 0x52, 0x44, 0x33, 0x17, 0xa3, 0x59, 0x85, 0x82, 0x3d, 0xf0, 0x27,
+	0x2e, 0x8b, 0x11, 0x2c, 0xb2, 0xc8, 0xa3, 0x06, 0x94, 0x87, 0x78, 0xe0, 0x10, 0x36, 0x9e, 0x5a,
+	0xa0, 0xd5, 0xae, 0x8a, 0x19, 0x6e, 0x33, 0xa5, 0x19, 0x99, 0xe9, 0xcf, 0xa0, 0x22, 0xaa, 0x32,
+	0x4b, 0x97, 0x85, 0x30, 0xc2, 0x22, 0xc7, 0x0a, 0x51, 0x03, 0x2d, 0x2d, 0x09, 0xc1, 0x5e, 0xd5,
+	0x3d, 0x58, 0x37, 0xf1, 0xab, 0xc9, 0xcb, 0x2b, 0x5e, 0x1b, 0x11, 0xa8, 0x5c, 0x0c, 0xd4, 0x5b,
+	0xa5, 0x47, 0xdf, 0x80, 0x6a, 0x3c, 0x28, 0x03, 0xf3, 0x18, 0xb4, 0x96, 0x3d, 0x1e, 0xe0, 0x51,
+	0x0c, 0xcc, 0xe2, 0x82, 0xf1, 0xc8, 0x39, 0x21, 0xb2, 0x7e, 0x13, 0xb6, 0x52, 0x3d, 0xb1, 0x40,
+	0x0d, 0x50, 0x1f, 0x61, 0x3f, 0xd0, 0xed, 0x5d, 0x3e, 0xa4, 0xdc, 0xfe, 0x86, 0xba, 0x7e, 0x08,
+	0x9b, 0x29, 0xdf, 0xb0, 0x23, 0xdf, 0x00, 0x20, 0x99, 0xb0, 0xc2, 0xad, 0x3d, 0x77, 0xbe, 0x59,
+	0xda, 0x05, 0x2b, 0x7d, 0x0f, 0x94, 0xc7, 0xb6, 0xf7, 0x4e, 0x79, 0xd7, 0x7f, 0x92, 0x60, 0x4d,
+	0x70, 0xc2, 0xd0, 0xd4, 0xa0, 0x7c, 0x16, 0x0a, 0xa9, 0xa3, 0x92, 0x19, 0x09, 0xd0, 0x5d, 0x28,
+	0x85, 0xbb, 0x8e, 0x9d, 0x7e, 0x25, 0x44, 0xca, 0xf7, 0x25, 0xb7, 0x88, 0xb2, 0x2e, 0xa7, 0x1f,
+	0x93, 0xbc, 0xb8, 0x09, 0xf5, 0x7d, 0xb8, 0xce, 0xe1, 0xec, 0xd9, 0xfe, 0xe0, 0x2c, 0x24, 0xd6,
+	0x80, 0xe5, 0x20, 0x46, 0x98, 0x1d, 0x7e, 0xf0, 0x93, 0x39, 0x30, 0x43, 0x43, 0xfd, 0x09, 0x6c,
+	0x24, 0x9d, 0x31, 0x82, 0x0f, 0xa2, 0xbb, 0x34, 0xf0, 0xb6, 0x99, 0xe2, 0x2d, 0xb0, 0xe5, 0x57,
+	0xaa, 0xfe, 0x1e, 0xdc, 0xe0, 0x05, 0xbc, 0xca, 0xd6, 0xd2, 0x9f, 0x0a, 0xbb, 0x24, 0xb1, 0x83,
+	0xd0, 0xfb, 0x04, 0x01, 0x15, 0xd1, 0x6f, 0x56, 0x1a, 0x5b, 0x21, 0x82, 0x98, 0xfd, 0xe1, 0x8b,
+	0x2f, 0xf1, 0x80, 0x5e, 0xeb, 0x74, 0xa9, 0xff, 0x20, 0x81, 0x7a, 0xe0, 0x78, 0xcc, 0xa9, 0xb7,
+	0x77, 0x19, 0xdc, 0xb7, 0xbc, 0xf8, 0xa9, 0x27, 0x9e, 0xbc, 0xd5, 0x34, 0xbd, 0x38, 0x78, 0x3a,
+	0xca, 0x26, 0x5f, 0x13, 0xdd, 0xd4, 0x3e, 0xc5, 0x96, 0xf3, 0x75, 0x70, 0xfd, 0x15, 0x4c, 0xbe,
+	0x26, 0xdb, 0x80, 0xfc, 0x3e, 0x9a, 0xbc, 0xc4, 0xe1, 0x09, 0x8c, 0x04, 0xfa, 0x05, 0x6c, 0xa6,
+	0x20, 0x61, 0xf4, 0xee, 0x25, 0xcb, 0x55, 0x8d, 0xd3, 0x0b, 0x79, 0x31, 0x23, 0x74, 0x07, 0xae,
+	0x8d, 0xf1, 0x6b, 0xbf, 0xc7, 0xc3, 0x05, 0xdb, 0x34, 0x2e, 0xd4, 0x7f, 0x91, 0x60, 0x3b, 0x8a,
+	0xc9, 0x68, 0x7b, 0x7b, 0x97, 0x16, 0xed, 0x3c, 0xc2, 0x34, 0x88, 0xad, 0x89, 0x94, 0x68, 0x4d,
+	0xfe, 0x99, 0x54, 0x7c, 0x27, 0x81, 0xbe, 0x08, 0x17, 0x4b, 0xca, 0x87, 0x50, 0x62, 0x75, 0x0c,
+	0xb3, 0xb2, 0xb0, 0xe8, 0xdc, 0xf8, 0x8a, 0xd9, 0xf9, 0x0a, 0xb6, 0x02, 0x10, 0xe3, 0xa1, 0x33,
+	0x3e, 0x0d, 0xdf, 0x4e, 0xfe, 0x5e, 0xf1, 0x77, 0x50, 0x48, 0x8c, 0x20, 0x89, 0xd1, 0xcf, 0x2d,
+	0xa2, 0x2f, 0x27, 0xe9, 0x7f, 0x03, 0xb5, 0xf4, 0xc0, 0xff, 0x0e, 0xef, 0xcf, 0x60, 0xfd, 0x39,
+	0x39, 0xdd, 0x6c, 0x27, 0xbe, 0xc3, 0x13, 0x34, 0x7f, 0x51, 0xe9, 0x6d, 0xa8, 0xc6, 0x9d, 0x33,
+	0x4e, 0x77, 0x81, 0x75, 0xce, 0xec, 0xf8, 0xa6, 0xef, 0xef, 0xb0, 0xbb, 0xfe, 0x93, 0x75, 0x55,
+	0x16, 0x7f, 0x6a, 0x69, 0xd0, 0x61, 0xec, 0xa0, 0x0e, 0x33, 0x9f, 0xe6, 0x1a, 0x94, 0x07, 0xb4,
+	0x51, 0x1c, 0x36, 0x7d, 0x0a, 0x4f, 0x36, 0x23, 0x01, 0xd1, 0xce, 0xa6, 0x43, 0xa6, 0xcd, 0x07,
+	0x5a, 0x2e, 0x20, 0x5a, 0x72, 0x17, 0x0f, 0xa9, 0xb6, 0x10, 0x68, 0xb9, 0x80, 0x9c, 0xd3, 0x33,
+	0xc7, 0xf3, 0x27, 0xee, 0x25, 0x6d, 0xa4, 0x05, 0x1e, 0x01, 0xd4, 0xd6, 0x99, 0x3d, 0x3e, 0xc5,
+	0x66, 0x68, 0x14, 0x6f, 0xa0, 0x97, 0x13, 0x0d, 0xb4, 0x7e, 0x06, 0x15, 0xf1, 0x33, 0x81, 0x8f,
+	0x94, 0xde, 0x0c, 0xe5, 0xd2, 0x5f, 0xfb, 0x58, 0x03, 0x42, 0x46, 0x00, 0xdf, 0x39, 0xc7, 0x8c,
+	0x1a, 0xfd, 0xad, 0xbf, 0xc9, 0x41, 0x45, 0xcc, 0x74, 0x76, 0x37, 0xc6, 0xf6, 0x40, 0x2e, 0x63,
+	0x0f, 0xc8, 0x19, 0xbd, 0x51, 0x3e, 0xbb, 0x00, 0x85, 0x85, 0x05, 0x28, 0x2e, 0x2c, 0xc0, 0xf2,
+	0x82, 0x02, 0x94, 0xde, 0xba, 0x00, 0xe5, 0x64, 0x01, 0x7e, 0x94, 0x61, 0x3d, 0xe5, 0x48, 0xbd,
+	0x65, 0x76, 0xc4, 0x09, 0x4a, 0x4e, 0x4c, 0x50, 0xe2, 0xe5, 0x9a, 0x4f, 0x5c, 0xae, 0xb1, 0xb9,
+	0xac, 0x90, 0x9c, 0xcb, 0xe6, 0x27, 0xbe, 0xe2, 0xc2, 0x89, 0x6f, 0x59, 0x98, 0xf8, 0xf8, 0x44,
+	0x59, 0xca, 0x9e, 0x28, 0xe3, 0x2d, 0x54, 0xf9, 0x2a, 0x2d, 0x54, 0xbc, 0xae, 0x90, 0x52, 0xd7,
+	0x28, 0xd7, 0x2b, 0xc9, 0x69, 0x31, 0x3e, 0x57, 0x54, 0x92, 0x73, 0xc5, 0xee, 0x1b, 0x09, 0x94,
+	0xe4, 0x34, 0x84, 0xaa, 0xa0, 0xf4, 0x2d, 0xc3, 0x3c, 0x36, 0x0d, 0xab, 0x7f, 0x70, 0x74, 0xdc,
+	0x3d, 0xec, 0x1a, 0xca, 0x12, 0xba, 0x01, 0xeb, 0xa2, 0xb4, 0x65, 0x1a, 0xcd, 0x23, 0xa3, 0xad,
+	0x48, 0xa8, 0x0e, 0x35, 0x51, 0xd1, 0x3c, 0x30, 0x8d, 0x66, 0xfb, 0x93, 0xe3, 0x66, 0xaf, 0x67,
+	0x1e, 0x3e, 0x33, 0xda, 0x4a, 0x2e, 0xf9, 0xa9, 0xb5, 0xdf, 0xe9, 0xf5, 0x8c, 0xb6, 0x22, 0xa3,
+	0x0d, 0x40, 0xa2, 0xe2, 0x61, 0xb3, 0x73, 0x60, 0xb4, 0x95, 0xfc, 0xee, 0x05, 0x28, 0xc9, 0x51,
+	0x09, 0xad, 0xc3, 0x7f, 0xac, 0xfe, 0xde, 0x93, 0x8e, 0x65, 0x75, 0x0e, 0xbb, 0x21, 0x28, 0x15,
+	0xaa, 0x82, 0x90, 0xfe, 0x3c, 0x0a, 0x50, 0x5d, 0x87, 0x35, 0x41, 0xf3, 0xb4, 0x6f, 0xf4, 0x43,
+	0x28, 0x82, 0xd8, 0x34, 0x3e, 0x32, 0x5a, 0xc4, 0x5e, 0xde, 0xfd, 0x55, 0x0a, 0x0f, 0x2b, 0x8b,
+	0x87, 0x60, 0xd5, 0x3a, 0x6a, 0x1e, 0xf5, 0xad, 0xe3, 0x9e, 0xd1, 0x6d, 0x77, 0xba, 0x8f, 0x94,
+	0x25, 0x8a, 0x21, 0x90, 0x71, 0x76, 0x12, 0x5a, 0x83, 0x6b, 0x4c, 0xd8, 0x36, 0xba, 0x1d, 0x1a,
+	0x25, 0xfa, 0xd6, 0x34, 0x9e, 0x1d, 0xee, 0x53, 0xae, 0x91, 0xcc, 0xf8, 0xb8, 0xd7, 0x31, 0x09,
+	0x4f, 0x92, 0x69, 0x26, 0x6b, 0x35, 0xbb, 0x2d, 0xe3, 0x80, 0xb0, 0x2f, 0xa0, 0x2d, 0xb8, 0xc1,
+	0xa4, 0x41, 0x42, 0x8e, 0x8f, 0x0e, 0x19, 0x35, 0xa5, 0xb8, 0xeb, 0x43, 0x89, 0x4f, 0x4f, 0x6b,
+	0x70, 0xad, 0x6d, 0xb4, 0x3a, 0x62, 0x42, 0xaa, 0xa0, 0x70, 0xd1, 0x23, 0xb3, 0xd9, 0x0d, 0x92,
+	0x21, 0x4a, 0x43, 0x36, 0x39, 0xc2, 0x86, 0x4b, 0x19, 0x74, 0x39, 0x66, 0xca, 0x81, 0x36, 0x7e,
+	0x2f, 0x85, 0x4f, 0x09, 0xda, 0x87, 0x8a, 0xf8, 0x87, 0x00, 0xe2, 0xcf, 0x64, 0xca, 0x3f, 0x33,
+	0x5a, 0x2d, 0x5d, 0xc9, 0x26, 0x94, 0x25, 0xf4, 0x39, 0xa0, 0xf9, 0xb9, 0x0d, 0x6d, 0xf3, 0x13,
+	0x91, 0x35, 0xd8, 0x6a, 0xfa, 0x22, 0x13, 0xee, 0xfe, 0x53, 0x58, 0x9b, 0x1b, 0x67, 0x50, 0x3d,
+	0xfc, 0x34, 0x6b, 0x3a, 0xd2, 0xb6, 0x17, 0x58, 0x70, 0xdf, 0x7b, 0x50, 0xe6, 0x7d, 0x38, 0xca,
+	0x6c, 0xf4, 0xb5, 0xec, 0xa6, 0x5d, 0x5f, 0x42, 0x4f, 0x61, 0x35, 0xde, 0xfc, 0xa3, 0x9b, 0x73,
+	0xe6, 0xe2, 0x84, 0xa1, 0xdd, 0xca, 0x52, 0x73, 0x97, 0xcf, 0x41, 0x49, 0xf6, 0xf3, 0xe8, 0xbf,
+	0x73, 0x7c, 0xe2, 0xa3, 0x81, 0x56, 0xcf, 0x36, 0xe0, 0x8e, 0xf7, 0xa1, 0x22, 0xce, 0xb3, 0x51,
+	0xdd, 0x53, 0x46, 0x6b, 0xad, 0x96, 0xae, 0xe4, 0xce, 0xbe, 0x80, 0xf5, 0x94, 0xd1, 0x15, 0xf1,
+	0xaa, 0x66, 0x4f, 0xc8, 0xda, 0xed, 0x85, 0x36, 0x62, 0xe9, 0xe7, 0x3a, 0xff, 0xa8, 0xf4, 0x59,
+	0xe3, 0x89, 0xb6, 0xbd, 0xc0, 0x82, 0xfb, 0x9e, 0x81, 0x96, 0xdd, 0x49, 0xa3, 0xff, 0xcf, 0xbb,
+	0xc8, 0x98, 0x02, 0xb4, 0xdd, 0xab, 0x98, 0xf2, 0xb0, 0x03, 0xa8, 0xa6, 0xb5, 0xb0, 0xe8, 0x76,
+	0xdc, 0x4b, 0x6a, 0x67, 0xad, 0xdd, 0x59, 0x6c, 0xc4, 0x83, 0x3c, 0x81, 0x8a, 0xd8, 0x4b, 0x46,
+	0x65, 0x4e, 0x69, 0x5f, 0xb5, 0x5a, 0xba, 0x32, 0x74, 0x76, 0x5f, 0x7a, 0x51, 0xa4, 0xff, 0xe0,
+	0x3e, 0xf8, 0x6b, 0x00, 0x0c, 0xb1, 0xa5, 0x75, 0xd1, 0x15, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -607,6 +2165,14 @@ type PermitClient interface {
 	UpdatePermitStatus(ctx context.Context, in *UpdatePermitStatusRequest, opts ...grpc.CallOption) (*UpdatePermitStatusResponse, error)
 	GetPermitByFileID(ctx context.Context, in *GetPermitByFileIDRequest, opts ...grpc.CallOption) (*GetPermitByFileIDResponse, error)
 	HasPermit(ctx context.Context, in *HasPermitRequest, opts ...grpc.CallOption) (*HasPermitResponse, error)
+	HasPermitBatch(ctx context.Context, in *HasPermitBatchRequest, opts ...grpc.CallOption) (*HasPermitBatchResponse, error)
+	GetPermitRequest(ctx context.Context, in *GetPermitRequestRequest, opts ...grpc.CallOption) (*GetPermitRequestResponse, error)
+	RevokePermit(ctx context.Context, in *RevokePermitRequest, opts ...grpc.CallOption) (*RevokePermitResponse, error)
+	CancelPermitRequest(ctx context.Context, in *CancelPermitRequestRequest, opts ...grpc.CallOption) (*CancelPermitRequestResponse, error)
+	ListPermitsByUser(ctx context.Context, in *ListPermitsByUserRequest, opts ...grpc.CallOption) (*ListPermitsByUserResponse, error)
+	ListPermitRequestsBySharer(ctx context.Context, in *ListPermitRequestsBySharerRequest, opts ...grpc.CallOption) (*ListPermitRequestsBySharerResponse, error)
+	ListPendingApprovals(ctx context.Context, in *ListPendingApprovalsRequest, opts ...grpc.CallOption) (*ListPendingApprovalsResponse, error)
+	WatchPermits(ctx context.Context, in *WatchPermitsRequest, opts ...grpc.CallOption) (Permit_WatchPermitsClient, error)
 }
 
 type permitClient struct {
@@ -653,12 +2219,115 @@ func (c *permitClient) HasPermit(ctx context.Context, in *HasPermitRequest, opts
 	return out, nil
 }
 
+func (c *permitClient) HasPermitBatch(ctx context.Context, in *HasPermitBatchRequest, opts ...grpc.CallOption) (*HasPermitBatchResponse, error) {
+	out := new(HasPermitBatchResponse)
+	err := c.cc.Invoke(ctx, "/permit.permit/HasPermitBatch", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *permitClient) GetPermitRequest(ctx context.Context, in *GetPermitRequestRequest, opts ...grpc.CallOption) (*GetPermitRequestResponse, error) {
+	out := new(GetPermitRequestResponse)
+	err := c.cc.Invoke(ctx, "/permit.permit/GetPermitRequest", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *permitClient) RevokePermit(ctx context.Context, in *RevokePermitRequest, opts ...grpc.CallOption) (*RevokePermitResponse, error) {
+	out := new(RevokePermitResponse)
+	err := c.cc.Invoke(ctx, "/permit.permit/RevokePermit", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *permitClient) CancelPermitRequest(ctx context.Context, in *CancelPermitRequestRequest, opts ...grpc.CallOption) (*CancelPermitRequestResponse, error) {
+	out := new(CancelPermitRequestResponse)
+	err := c.cc.Invoke(ctx, "/permit.permit/CancelPermitRequest", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *permitClient) ListPermitsByUser(ctx context.Context, in *ListPermitsByUserRequest, opts ...grpc.CallOption) (*ListPermitsByUserResponse, error) {
+	out := new(ListPermitsByUserResponse)
+	err := c.cc.Invoke(ctx, "/permit.permit/ListPermitsByUser", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *permitClient) ListPermitRequestsBySharer(ctx context.Context, in *ListPermitRequestsBySharerRequest, opts ...grpc.CallOption) (*ListPermitRequestsBySharerResponse, error) {
+	out := new(ListPermitRequestsBySharerResponse)
+	err := c.cc.Invoke(ctx, "/permit.permit/ListPermitRequestsBySharer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *permitClient) ListPendingApprovals(ctx context.Context, in *ListPendingApprovalsRequest, opts ...grpc.CallOption) (*ListPendingApprovalsResponse, error) {
+	out := new(ListPendingApprovalsResponse)
+	err := c.cc.Invoke(ctx, "/permit.permit/ListPendingApprovals", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *permitClient) WatchPermits(ctx context.Context, in *WatchPermitsRequest, opts ...grpc.CallOption) (Permit_WatchPermitsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Permit_serviceDesc.Streams[0], "/permit.permit/WatchPermits", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &permitWatchPermitsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Permit_WatchPermitsClient interface {
+	Recv() (*WatchPermitsResponse, error)
+	grpc.ClientStream
+}
+
+type permitWatchPermitsClient struct {
+	grpc.ClientStream
+}
+
+func (x *permitWatchPermitsClient) Recv() (*WatchPermitsResponse, error) {
+	m := new(WatchPermitsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // PermitServer is the server API for Permit service.
 type PermitServer interface {
 	CreatePermit(context.Context, *CreatePermitRequest) (*CreatePermitResponse, error)
 	UpdatePermitStatus(context.Context, *UpdatePermitStatusRequest) (*UpdatePermitStatusResponse, error)
 	GetPermitByFileID(context.Context, *GetPermitByFileIDRequest) (*GetPermitByFileIDResponse, error)
 	HasPermit(context.Context, *HasPermitRequest) (*HasPermitResponse, error)
+	HasPermitBatch(context.Context, *HasPermitBatchRequest) (*HasPermitBatchResponse, error)
+	GetPermitRequest(context.Context, *GetPermitRequestRequest) (*GetPermitRequestResponse, error)
+	RevokePermit(context.Context, *RevokePermitRequest) (*RevokePermitResponse, error)
+	CancelPermitRequest(context.Context, *CancelPermitRequestRequest) (*CancelPermitRequestResponse, error)
+	ListPermitsByUser(context.Context, *ListPermitsByUserRequest) (*ListPermitsByUserResponse, error)
+	ListPermitRequestsBySharer(context.Context, *ListPermitRequestsBySharerRequest) (*ListPermitRequestsBySharerResponse, error)
+	ListPendingApprovals(context.Context, *ListPendingApprovalsRequest) (*ListPendingApprovalsResponse, error)
+	WatchPermits(*WatchPermitsRequest, Permit_WatchPermitsServer) error
 }
 
 // UnimplementedPermitServer can be embedded to have forward compatible implementations.
@@ -677,6 +2346,30 @@ func (*UnimplementedPermitServer) GetPermitByFileID(ctx context.Context, req *Ge
 func (*UnimplementedPermitServer) HasPermit(ctx context.Context, req *HasPermitRequest) (*HasPermitResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method HasPermit not implemented")
 }
+func (*UnimplementedPermitServer) HasPermitBatch(ctx context.Context, req *HasPermitBatchRequest) (*HasPermitBatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method HasPermitBatch not implemented")
+}
+func (*UnimplementedPermitServer) GetPermitRequest(ctx context.Context, req *GetPermitRequestRequest) (*GetPermitRequestResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPermitRequest not implemented")
+}
+func (*UnimplementedPermitServer) RevokePermit(ctx context.Context, req *RevokePermitRequest) (*RevokePermitResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokePermit not implemented")
+}
+func (*UnimplementedPermitServer) CancelPermitRequest(ctx context.Context, req *CancelPermitRequestRequest) (*CancelPermitRequestResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelPermitRequest not implemented")
+}
+func (*UnimplementedPermitServer) ListPermitsByUser(ctx context.Context, req *ListPermitsByUserRequest) (*ListPermitsByUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPermitsByUser not implemented")
+}
+func (*UnimplementedPermitServer) ListPermitRequestsBySharer(ctx context.Context, req *ListPermitRequestsBySharerRequest) (*ListPermitRequestsBySharerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPermitRequestsBySharer not implemented")
+}
+func (*UnimplementedPermitServer) ListPendingApprovals(ctx context.Context, req *ListPendingApprovalsRequest) (*ListPendingApprovalsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPendingApprovals not implemented")
+}
+func (*UnimplementedPermitServer) WatchPermits(req *WatchPermitsRequest, srv Permit_WatchPermitsServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchPermits not implemented")
+}
 
 func RegisterPermitServer(s *grpc.Server, srv PermitServer) {
 	s.RegisterService(&_Permit_serviceDesc, srv)
@@ -754,6 +2447,153 @@ func _Permit_HasPermit_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Permit_HasPermitBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HasPermitBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PermitServer).HasPermitBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/permit.permit/HasPermitBatch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PermitServer).HasPermitBatch(ctx, req.(*HasPermitBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Permit_GetPermitRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPermitRequestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PermitServer).GetPermitRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/permit.permit/GetPermitRequest",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PermitServer).GetPermitRequest(ctx, req.(*GetPermitRequestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Permit_RevokePermit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokePermitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PermitServer).RevokePermit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/permit.permit/RevokePermit",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PermitServer).RevokePermit(ctx, req.(*RevokePermitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Permit_CancelPermitRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelPermitRequestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PermitServer).CancelPermitRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/permit.permit/CancelPermitRequest",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PermitServer).CancelPermitRequest(ctx, req.(*CancelPermitRequestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Permit_ListPermitsByUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPermitsByUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PermitServer).ListPermitsByUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/permit.permit/ListPermitsByUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PermitServer).ListPermitsByUser(ctx, req.(*ListPermitsByUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Permit_ListPermitRequestsBySharer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPermitRequestsBySharerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PermitServer).ListPermitRequestsBySharer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/permit.permit/ListPermitRequestsBySharer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PermitServer).ListPermitRequestsBySharer(ctx, req.(*ListPermitRequestsBySharerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Permit_ListPendingApprovals_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPendingApprovalsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PermitServer).ListPendingApprovals(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/permit.permit/ListPendingApprovals",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PermitServer).ListPendingApprovals(ctx, req.(*ListPendingApprovalsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Permit_WatchPermits_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchPermitsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PermitServer).WatchPermits(m, &permitWatchPermitsServer{stream})
+}
+
+type Permit_WatchPermitsServer interface {
+	Send(*WatchPermitsResponse) error
+	grpc.ServerStream
+}
+
+type permitWatchPermitsServer struct {
+	grpc.ServerStream
+}
+
+func (x *permitWatchPermitsServer) Send(m *WatchPermitsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 var _Permit_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "permit.permit",
 	HandlerType: (*PermitServer)(nil),
@@ -774,7 +2614,41 @@ var _Permit_serviceDesc = grpc.ServiceDesc{
 			MethodName: "HasPermit",
 			Handler:    _Permit_HasPermit_Handler,
 		},
+		{
+			MethodName: "HasPermitBatch",
+			Handler:    _Permit_HasPermitBatch_Handler,
+		},
+		{
+			MethodName: "GetPermitRequest",
+			Handler:    _Permit_GetPermitRequest_Handler,
+		},
+		{
+			MethodName: "RevokePermit",
+			Handler:    _Permit_RevokePermit_Handler,
+		},
+		{
+			MethodName: "CancelPermitRequest",
+			Handler:    _Permit_CancelPermitRequest_Handler,
+		},
+		{
+			MethodName: "ListPermitsByUser",
+			Handler:    _Permit_ListPermitsByUser_Handler,
+		},
+		{
+			MethodName: "ListPermitRequestsBySharer",
+			Handler:    _Permit_ListPermitRequestsBySharer_Handler,
+		},
+		{
+			MethodName: "ListPendingApprovals",
+			Handler:    _Permit_ListPendingApprovals_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchPermits",
+			Handler:       _Permit_WatchPermits_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "permit.proto",
 }
@@ -0,0 +1,29 @@
+package service
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ApprovalClient is an interface for filing the approval requests of permits with the approval service.
+// Errors are status errors, the approval service rejecting a request is a codes.InvalidArgument or
+// codes.FailedPrecondition error with the approval service's message, see IsPermanentError.
+// Creating an approval request that was already filed is a codes.AlreadyExists error, and any other
+// error, including those of this service being misconfigured or unauthorized, may succeed if retried.
+type ApprovalClient interface {
+	CreateApprovalRequest(ctx context.Context, request ApprovalReqType) (string, error)
+	CancelApprovalRequest(ctx context.Context, reqID string) error
+}
+
+// IsPermanentError returns true if err is an error of the approval service rejecting a request,
+// which fails again if retried, and false if the request may succeed if retried.
+func IsPermanentError(err error) bool {
+	switch status.Code(err) {
+	case codes.InvalidArgument, codes.FailedPrecondition:
+		return true
+	default:
+		return false
+	}
+}
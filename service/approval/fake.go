@@ -0,0 +1,74 @@
+package approval
+
+import (
+	"context"
+	"sync"
+
+	"github.com/meateam/permit-service/service"
+)
+
+var _ service.ApprovalClient = &FakeClient{}
+
+// FakeClient is an in-process service.ApprovalClient which records the approval requests
+// filed with it instead of sending them, for testing the service without an approval service.
+type FakeClient struct {
+	mu        sync.Mutex
+	err       error
+	created   []service.ApprovalReqType
+	cancelled []string
+}
+
+// NewFakeClient creates a FakeClient.
+func NewFakeClient() *FakeClient {
+	return &FakeClient{}
+}
+
+// SetError sets the error returned from the calls to the client, nil for succeeding.
+func (c *FakeClient) SetError(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.err = err
+}
+
+// CreateApprovalRequest records request, unless the client is set to fail,
+// the identifier it gives request is its reqID.
+func (c *FakeClient) CreateApprovalRequest(ctx context.Context, request service.ApprovalReqType) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err != nil {
+		return "", c.err
+	}
+
+	c.created = append(c.created, request)
+
+	return request.ID, nil
+}
+
+// CancelApprovalRequest records the cancellation of reqID, unless the client is set to fail.
+func (c *FakeClient) CancelApprovalRequest(ctx context.Context, reqID string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err != nil {
+		return c.err
+	}
+
+	c.cancelled = append(c.cancelled, reqID)
+
+	return nil
+}
+
+// Created returns the approval requests filed with the client.
+func (c *FakeClient) Created() []service.ApprovalReqType {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return append([]service.ApprovalReqType(nil), c.created...)
+}
+
+// Cancelled returns the reqIDs of the approval requests cancelled with the client.
+func (c *FakeClient) Cancelled() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return append([]string(nil), c.cancelled...)
+}
@@ -0,0 +1,110 @@
+package approval
+
+import (
+	"context"
+	"fmt"
+
+	apb "github.com/meateam/permit-service/proto/approval"
+	"github.com/meateam/permit-service/service"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+var _ service.ApprovalClient = &GRPCClient{}
+
+// GRPCClient is a service.ApprovalClient of the gRPC API of the approval service.
+type GRPCClient struct {
+	client apb.ApprovalClient
+	tokens service.TokenSource
+}
+
+// NewGRPCClient creates a GRPCClient of the approval service connected to by conn,
+// which authenticates with the tokens of tokens.
+func NewGRPCClient(conn *grpc.ClientConn, tokens service.TokenSource) *GRPCClient {
+	return &GRPCClient{client: apb.NewApprovalClient(conn), tokens: tokens}
+}
+
+// CreateApprovalRequest files request with the approval service,
+// and returns the identifier the approval service gave it.
+func (c *GRPCClient) CreateApprovalRequest(ctx context.Context, request service.ApprovalReqType) (string, error) {
+	ctx, err := c.authenticate(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	users := make([]*apb.User, 0, len(request.To))
+	for _, user := range request.To {
+		users = append(users, &apb.User{Id: user.ID, Name: user.Name})
+	}
+
+	res, err := c.client.CreateApprovalRequest(ctx, &apb.CreateApprovalRequestRequest{
+		Id:             request.ID,
+		From:           request.From,
+		Approvers:      request.Approvers,
+		To:             users,
+		FileId:         request.FileID,
+		FileName:       request.FileName,
+		Info:           request.Info,
+		Classification: request.Classification,
+		ExpiresAt:      request.ExpiresAt,
+	})
+	if err != nil {
+		return "", grpcStatusError(err)
+	}
+
+	return res.GetId(), nil
+}
+
+// CancelApprovalRequest cancels the approval request reqID with the approval service,
+// an approval request that the approval service doesn't have is considered cancelled.
+func (c *GRPCClient) CancelApprovalRequest(ctx context.Context, reqID string) error {
+	ctx, err := c.authenticate(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.client.CancelApprovalRequest(ctx, &apb.CancelApprovalRequestRequest{Id: reqID})
+	if status.Code(err) == codes.NotFound {
+		return nil
+	}
+
+	// An approval request that can't be cancelled anymore is a rejection of the cancellation.
+	if status.Code(err) == codes.AlreadyExists {
+		return status.Error(codes.FailedPrecondition, status.Convert(err).Message())
+	}
+
+	return grpcStatusError(err)
+}
+
+// authenticate returns ctx with the authorization metadata of a token.
+func (c *GRPCClient) authenticate(ctx context.Context) (context.Context, error) {
+	token, err := c.tokens.Token(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return metadata.AppendToOutgoingContext(ctx, "authorization", fmt.Sprintf("Bearer %s", token)), nil
+}
+
+// grpcStatusError returns the error of the approval service err as a service.ApprovalClient error.
+// Rejections of the request itself keep the approval service's message and map to codes which aren't
+// retried, while the errors of this service being misconfigured or unauthorized map to codes.Unavailable.
+func grpcStatusError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	st := status.Convert(err)
+	switch st.Code() {
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.AlreadyExists:
+		return err
+	case codes.OutOfRange:
+		return status.Errorf(codes.FailedPrecondition, "approval service rejected the request: %s", st.Message())
+	case codes.Unauthenticated, codes.PermissionDenied, codes.NotFound, codes.Unimplemented:
+		return status.Errorf(codes.Unavailable, "approval service responded with %s: %s", st.Code(), st.Message())
+	default:
+		return err
+	}
+}
@@ -0,0 +1,37 @@
+package approval
+
+import (
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGRPCStatusError(t *testing.T) {
+	tests := []struct {
+		code codes.Code
+		want codes.Code
+	}{
+		{codes.InvalidArgument, codes.InvalidArgument},
+		{codes.FailedPrecondition, codes.FailedPrecondition},
+		{codes.AlreadyExists, codes.AlreadyExists},
+		{codes.OutOfRange, codes.FailedPrecondition},
+		{codes.Unauthenticated, codes.Unavailable},
+		{codes.PermissionDenied, codes.Unavailable},
+		{codes.NotFound, codes.Unavailable},
+		{codes.Unimplemented, codes.Unavailable},
+		{codes.Unavailable, codes.Unavailable},
+		{codes.DeadlineExceeded, codes.DeadlineExceeded},
+		{codes.Internal, codes.Internal},
+	}
+
+	for _, tt := range tests {
+		if got := status.Code(grpcStatusError(status.Error(tt.code, "approval service error"))); got != tt.want {
+			t.Errorf("grpcStatusError(%v) code = %v, want %v", tt.code, got, tt.want)
+		}
+	}
+
+	if err := grpcStatusError(nil); err != nil {
+		t.Errorf("grpcStatusError(nil) = %v, want nil", err)
+	}
+}
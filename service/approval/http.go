@@ -0,0 +1,169 @@
+package approval
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/meateam/permit-service/service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxResponseSize is the maximum size of an approval service response that is read.
+const maxResponseSize = 1 << 20
+
+var _ service.ApprovalClient = &HTTPClient{}
+
+// HTTPClient is a service.ApprovalClient of the HTTP API of the approval service.
+type HTTPClient struct {
+	url    string
+	tokens service.TokenSource
+	client *http.Client
+}
+
+// NewHTTPClient creates an HTTPClient of the approval service at url,
+// which authenticates with the tokens of tokens over TLS connections configured by tlsConfig.
+func NewHTTPClient(url string, tokens service.TokenSource, tlsConfig *tls.Config) *HTTPClient {
+	tr := &http.Transport{
+		Proxy:           http.ProxyFromEnvironment,
+		TLSClientConfig: tlsConfig,
+	}
+
+	return &HTTPClient{url: url, tokens: tokens, client: &http.Client{Transport: tr}}
+}
+
+// CreateApprovalRequest posts request to the approval service,
+// and returns the identifier the approval service gave it, if it responded with one.
+func (c *HTTPClient) CreateApprovalRequest(ctx context.Context, request service.ApprovalReqType) (string, error) {
+	requestBody, err := json.Marshal(request)
+	if err != nil {
+		return "", status.Errorf(codes.Internal, "failed creating json object: %v", err)
+	}
+
+	res, _, err := c.do(ctx, http.MethodPost, c.url, bytes.NewBuffer(requestBody))
+	if err != nil {
+		return "", err
+	}
+
+	return res.ID, nil
+}
+
+// CancelApprovalRequest deletes the approval request reqID from the approval service,
+// an approval request that the approval service doesn't have is considered cancelled.
+func (c *HTTPClient) CancelApprovalRequest(ctx context.Context, reqID string) error {
+	_, statusCode, err := c.do(ctx, http.MethodDelete, fmt.Sprintf("%s/%s", strings.TrimSuffix(c.url, "/"), reqID), nil)
+	if statusCode == http.StatusNotFound {
+		return nil
+	}
+
+	// An approval request that can't be cancelled anymore is a rejection of the cancellation.
+	if status.Code(err) == codes.AlreadyExists {
+		return status.Error(codes.FailedPrecondition, status.Convert(err).Message())
+	}
+
+	return err
+}
+
+// do sends an authenticated request of method to url with body, and returns its parsed response
+// and its status code, which is 0 if there is no response.
+// Returns a status error of the code matching the response's status code if it's not a 2xx,
+// with the message of the approval service.
+func (c *HTTPClient) do(ctx context.Context, method string, url string, body io.Reader) (approvalResponse, int, error) {
+	token, err := c.tokens.Token(ctx)
+	if err != nil {
+		return approvalResponse{}, 0, err
+	}
+
+	httpReq, err := http.NewRequest(method, url, body)
+	if err != nil {
+		return approvalResponse{}, 0, status.Errorf(codes.Internal, "failed creating http request to approval service: %v", err)
+	}
+
+	httpReq.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	httpReq.Header.Set("Content-Type", "application/json")
+	resp, err := c.client.Do(httpReq.WithContext(ctx))
+	if err != nil {
+		return approvalResponse{}, 0, status.Errorf(codes.Unavailable, "approval service is unavailable: %v", err)
+	}
+
+	defer resp.Body.Close()
+
+	respBody, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return approvalResponse{}, resp.StatusCode, status.Errorf(codes.Unavailable, "failed reading approval service response: %v", err)
+	}
+
+	res := approvalResponse{}
+	parseErr := json.Unmarshal(respBody, &res)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		message := res.message()
+		if parseErr != nil || message == "" {
+			message = strings.TrimSpace(string(respBody))
+		}
+
+		if message == "" {
+			message = http.StatusText(resp.StatusCode)
+		}
+
+		return approvalResponse{}, resp.StatusCode, status.Errorf(
+			httpStatusCode(resp.StatusCode),
+			"approval service responded with %d: %s",
+			resp.StatusCode,
+			message,
+		)
+	}
+
+	if len(bytes.TrimSpace(respBody)) > 0 && parseErr != nil {
+		return approvalResponse{}, resp.StatusCode, status.Errorf(codes.Internal, "invalid approval service response: %v", parseErr)
+	}
+
+	return res, resp.StatusCode, nil
+}
+
+// approvalResponse is the json body of the responses of the approval service.
+type approvalResponse struct {
+	ID      string `json:"id"`
+	Message string `json:"message"`
+	Error   string `json:"error"`
+}
+
+// message returns the message of the error response r.
+func (r approvalResponse) message() string {
+	if r.Message != "" {
+		return r.Message
+	}
+
+	return r.Error
+}
+
+// httpStatusCode returns the grpc code of the http status code of a failed request. Rejections of the
+// request itself map to codes which aren't retried, while the rest map to codes which are retried.
+// Unauthorized, forbidden and not found responses are retried, since they are usually caused by this
+// service being misconfigured rather than by the request.
+func httpStatusCode(statusCode int) codes.Code {
+	switch statusCode {
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return codes.InvalidArgument
+	case http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound:
+		return codes.Unavailable
+	case http.StatusConflict:
+		return codes.AlreadyExists
+	case http.StatusTooManyRequests:
+		return codes.ResourceExhausted
+	case http.StatusRequestTimeout:
+		return codes.DeadlineExceeded
+	}
+
+	if statusCode >= 400 && statusCode < 500 {
+		return codes.FailedPrecondition
+	}
+
+	return codes.Unavailable
+}
@@ -0,0 +1,42 @@
+package approval
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/meateam/permit-service/service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestHTTPStatusCode(t *testing.T) {
+	tests := []struct {
+		statusCode int
+		want       codes.Code
+		permanent  bool
+	}{
+		{http.StatusBadRequest, codes.InvalidArgument, true},
+		{http.StatusUnprocessableEntity, codes.InvalidArgument, true},
+		{http.StatusGone, codes.FailedPrecondition, true},
+		{http.StatusUnauthorized, codes.Unavailable, false},
+		{http.StatusForbidden, codes.Unavailable, false},
+		{http.StatusNotFound, codes.Unavailable, false},
+		{http.StatusConflict, codes.AlreadyExists, false},
+		{http.StatusTooManyRequests, codes.ResourceExhausted, false},
+		{http.StatusRequestTimeout, codes.DeadlineExceeded, false},
+		{http.StatusInternalServerError, codes.Unavailable, false},
+		{http.StatusBadGateway, codes.Unavailable, false},
+		{http.StatusServiceUnavailable, codes.Unavailable, false},
+	}
+
+	for _, tt := range tests {
+		got := httpStatusCode(tt.statusCode)
+		if got != tt.want {
+			t.Errorf("httpStatusCode(%d) = %v, want %v", tt.statusCode, got, tt.want)
+		}
+
+		if permanent := service.IsPermanentError(status.Error(got, "")); permanent != tt.permanent {
+			t.Errorf("IsPermanentError of status %d = %v, want %v", tt.statusCode, permanent, tt.permanent)
+		}
+	}
+}
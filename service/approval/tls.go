@@ -0,0 +1,62 @@
+package approval
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+)
+
+// TLSConfig is the configuration of the TLS connections to the approval service.
+type TLSConfig struct {
+	// CAFile is the path of the PEM bundle of the CAs to verify the approval service with,
+	// the system's CAs are used if empty.
+	CAFile string
+
+	// CertFile and KeyFile are the paths of the PEM client certificate and key for mutual TLS,
+	// no client certificate is presented if empty.
+	CertFile string
+	KeyFile  string
+
+	// ServerName overrides the name the certificate of the approval service is verified against.
+	ServerName string
+
+	// Insecure skips verifying the certificate of the approval service, it must be explicitly opted in.
+	Insecure bool
+}
+
+// Load returns the tls.Config of c.
+// Returns a non-nil error if any of the configured files can't be loaded.
+func (c TLSConfig) Load() (*tls.Config, error) {
+	config := &tls.Config{
+		ServerName:         c.ServerName,
+		InsecureSkipVerify: c.Insecure,
+	}
+
+	if c.CAFile != "" {
+		ca, err := ioutil.ReadFile(c.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed reading CA bundle: %v", err)
+		}
+
+		config.RootCAs = x509.NewCertPool()
+		if !config.RootCAs.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf("no certificates found in CA bundle %s", c.CAFile)
+		}
+	}
+
+	if c.CertFile != "" || c.KeyFile != "" {
+		if c.CertFile == "" || c.KeyFile == "" {
+			return nil, fmt.Errorf("both a client certificate and key are required for mutual TLS")
+		}
+
+		cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed loading client certificate: %v", err)
+		}
+
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	return config, nil
+}
@@ -0,0 +1,36 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestIsPermanentError(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{nil, false},
+		{status.Error(codes.InvalidArgument, "invalid request"), true},
+		{status.Error(codes.FailedPrecondition, "file is classified"), true},
+		{status.Error(codes.AlreadyExists, "request already exists"), false},
+		{status.Error(codes.Unavailable, "approval service is down"), false},
+		{status.Error(codes.Unauthenticated, "invalid token"), false},
+		{status.Error(codes.PermissionDenied, "forbidden"), false},
+		{status.Error(codes.NotFound, "not found"), false},
+		{status.Error(codes.DeadlineExceeded, "timeout"), false},
+		{status.Error(codes.ResourceExhausted, "too many requests"), false},
+		{context.DeadlineExceeded, false},
+		{errors.New("connection refused"), false},
+	}
+
+	for _, tt := range tests {
+		if got := IsPermanentError(tt.err); got != tt.want {
+			t.Errorf("IsPermanentError(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
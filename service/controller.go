@@ -2,15 +2,62 @@ package service
 
 import (
 	"context"
+	"time"
 
 	pb "github.com/meateam/permit-service/proto"
 )
 
 // Controller is an interface for the business logic of the permit.Service which uses a Store.
 type Controller interface {
-	CreatePermit(ctx context.Context, reqID string, fileID string, userID string, status string) (Permit, error)
+	CreatePermits(ctx context.Context, request ApprovalReqType, change StatusChange, message OutboxMessage) ([]Permit, error)
 	GetPermitsByFileID(ctx context.Context, fileID string) ([]*pb.UserStatus, error)
-	HasPermit(ctx context.Context, fileID string, userID string) (bool, error)
-	UpdatePermitStatus(ctx context.Context, reqID string, status string) (bool, error)
+	GetPermit(ctx context.Context, fileID string, userID string) (Permit, error)
+	GetPermitsByKeys(ctx context.Context, keys []PermitKey) (map[PermitKey]Permit, error)
+	ListPermitsByUser(
+		ctx context.Context,
+		userID string,
+		statuses []string,
+		pageSize int64,
+		pageToken string,
+	) ([]Permit, string, error)
+	ListPermitRequestsBySharer(
+		ctx context.Context,
+		sharerID string,
+		statuses []string,
+		pageSize int64,
+		pageToken string,
+	) ([]RequestPermits, string, error)
+	ListPendingApprovals(
+		ctx context.Context,
+		approverID string,
+		pageSize int64,
+		pageToken string,
+	) ([]RequestPermits, string, error)
+	GetPermitRequest(ctx context.Context, reqID string) (PermitRequest, error)
+	GetPermitRequestByIdempotencyKey(ctx context.Context, sharerID string, idempotencyKey string) (PermitRequest, error)
+	GetPermitsByReqID(ctx context.Context, reqID string) ([]Permit, error)
+	UpdatePermitStatus(ctx context.Context, reqID string, fromStatuses []string, change StatusChange) (bool, error)
+	UpdateUserPermitStatuses(ctx context.Context, reqID string, updates []UserStatusUpdate) (int64, error)
+	RevokePermit(
+		ctx context.Context,
+		fileID string,
+		userID string,
+		fromStatuses []string,
+		change StatusChange,
+	) ([]Permit, error)
+	CancelPermits(
+		ctx context.Context,
+		reqID string,
+		fromStatuses []string,
+		change StatusChange,
+		message OutboxMessage,
+	) (int64, error)
+	CompleteApprovalRequest(ctx context.Context, reqID string, approvalID string) error
+	FailApprovalRequest(ctx context.Context, reqID string, fromStatuses []string, change StatusChange) (int64, error)
+	WatchPermits(ctx context.Context, filter WatchFilter, send func(permit Permit) error) error
+	ExpirePermits(ctx context.Context, fromStatuses []string, change StatusChange) ([]Permit, error)
+	ClaimOutboxMessage(ctx context.Context, now time.Time, lease time.Duration) (OutboxMessage, error)
+	RetryOutboxMessage(ctx context.Context, id string, nextAttemptAt time.Time, lastError string) error
+	DeleteOutboxMessage(ctx context.Context, id string) error
 	HealthCheck(ctx context.Context) (bool, error)
 }
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"time"
+
+	pb "github.com/meateam/permit-service/proto"
+)
+
+// EffectivePermit returns the permit that governs the access of a user to a file at the time now, out of
+// permits, the permits of the user to the file by all of its requests. It's the latest permit that grants
+// access, or the latest permit if none of them grant access. Returns nil if permits is empty.
+func EffectivePermit(permits []Permit, now time.Time) Permit {
+	var latest, latestGranted Permit
+	for _, permit := range permits {
+		if latest == nil || permit.GetCreatedAt().After(latest.GetCreatedAt()) {
+			latest = permit
+		}
+
+		if Decide(permit, now) != pb.Decision_DECISION_GRANTED {
+			continue
+		}
+
+		if latestGranted == nil || permit.GetCreatedAt().After(latestGranted.GetCreatedAt()) {
+			latestGranted = permit
+		}
+	}
+
+	if latestGranted != nil {
+		return latestGranted
+	}
+
+	return latest
+}
+
+// Decide evaluates permit at the time now and returns whether it grants access to its file.
+// A nil permit is evaluated as pb.Decision_DECISION_NONE.
+func Decide(permit Permit, now time.Time) pb.Decision {
+	if permit == nil {
+		return pb.Decision_DECISION_NONE
+	}
+
+	expiresAt := permit.GetExpiresAt()
+	if !expiresAt.IsZero() && !now.Before(expiresAt) {
+		return pb.Decision_DECISION_EXPIRED
+	}
+
+	switch permit.GetStatus() {
+	case StatusApproved:
+		return pb.Decision_DECISION_GRANTED
+	case StatusPending:
+		return pb.Decision_DECISION_PENDING
+	case StatusExpired:
+		return pb.Decision_DECISION_EXPIRED
+	default:
+		return pb.Decision_DECISION_DENIED
+	}
+}
+
+// EffectiveUserStatuses returns the status of the effective permit at the time now of each user out of
+// permits, the permits of a single file, in the order the users first appear in permits.
+func EffectiveUserStatuses(permits []Permit, now time.Time) []*pb.UserStatus {
+	userIDs := []string{}
+	permitsByUser := make(map[string][]Permit)
+	for _, permit := range permits {
+		if _, ok := permitsByUser[permit.GetUserID()]; !ok {
+			userIDs = append(userIDs, permit.GetUserID())
+		}
+
+		permitsByUser[permit.GetUserID()] = append(permitsByUser[permit.GetUserID()], permit)
+	}
+
+	userStatuses := make([]*pb.UserStatus, 0, len(userIDs))
+	for _, userID := range userIDs {
+		userStatuses = append(userStatuses, NewUserStatus(EffectivePermit(permitsByUser[userID], now)))
+	}
+
+	return userStatuses
+}
+
+// EffectivePermitsByKey returns the effective permit at the time now of each pair of file and user
+// out of permits, mapped by their keys.
+func EffectivePermitsByKey(permits []Permit, now time.Time) map[PermitKey]Permit {
+	keyPermits := make(map[PermitKey][]Permit)
+	for _, permit := range permits {
+		key := PermitKey{FileID: permit.GetFileID(), UserID: permit.GetUserID()}
+		keyPermits[key] = append(keyPermits[key], permit)
+	}
+
+	permitsByKey := make(map[PermitKey]Permit, len(keyPermits))
+	for key, permits := range keyPermits {
+		permitsByKey[key] = EffectivePermit(permits, now)
+	}
+
+	return permitsByKey
+}
@@ -0,0 +1,116 @@
+package service_test
+
+import (
+	"testing"
+	"time"
+
+	pb "github.com/meateam/permit-service/proto"
+	"github.com/meateam/permit-service/service"
+	"github.com/meateam/permit-service/service/mongodb"
+)
+
+func TestDecide(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+
+	tests := []struct {
+		name   string
+		permit service.Permit
+		want   pb.Decision
+	}{
+		{"nil permit", nil, pb.Decision_DECISION_NONE},
+		{"approved", &mongodb.BSON{Status: service.StatusApproved}, pb.Decision_DECISION_GRANTED},
+		{"approved until later", &mongodb.BSON{Status: service.StatusApproved, ExpiresAt: now.Add(time.Second)}, pb.Decision_DECISION_GRANTED},
+		{"approved until now", &mongodb.BSON{Status: service.StatusApproved, ExpiresAt: now}, pb.Decision_DECISION_EXPIRED},
+		{"approved until earlier", &mongodb.BSON{Status: service.StatusApproved, ExpiresAt: now.Add(-time.Second)}, pb.Decision_DECISION_EXPIRED},
+		{"pending", &mongodb.BSON{Status: service.StatusPending}, pb.Decision_DECISION_PENDING},
+		{"pending until earlier", &mongodb.BSON{Status: service.StatusPending, ExpiresAt: now.Add(-time.Second)}, pb.Decision_DECISION_EXPIRED},
+		{"expired", &mongodb.BSON{Status: service.StatusExpired}, pb.Decision_DECISION_EXPIRED},
+		{"denied", &mongodb.BSON{Status: service.StatusDenied}, pb.Decision_DECISION_DENIED},
+		{"revoked", &mongodb.BSON{Status: service.StatusRevoked}, pb.Decision_DECISION_DENIED},
+		{"cancelled", &mongodb.BSON{Status: service.StatusCancelled}, pb.Decision_DECISION_DENIED},
+		{"failed to submit", &mongodb.BSON{Status: service.StatusFailedToSubmit}, pb.Decision_DECISION_DENIED},
+	}
+
+	for _, tt := range tests {
+		if got := service.Decide(tt.permit, now); got != tt.want {
+			t.Errorf("%s: Decide() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestEffectivePermit(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	permit := func(reqID string, status string, createdAt time.Duration, expiresAt time.Time) service.Permit {
+		return &mongodb.BSON{ReqID: reqID, Status: status, CreatedAt: now.Add(createdAt), ExpiresAt: expiresAt}
+	}
+
+	tests := []struct {
+		name    string
+		permits []service.Permit
+		want    string
+	}{
+		{"no permits", nil, ""},
+		{"single permit", []service.Permit{
+			permit("a", service.StatusPending, -time.Hour, time.Time{}),
+		}, "a"},
+		{"latest permit", []service.Permit{
+			permit("a", service.StatusDenied, -2*time.Hour, time.Time{}),
+			permit("b", service.StatusPending, -time.Hour, time.Time{}),
+		}, "b"},
+		{"granted over a later pending permit", []service.Permit{
+			permit("a", service.StatusApproved, -2*time.Hour, time.Time{}),
+			permit("b", service.StatusPending, -time.Hour, time.Time{}),
+		}, "a"},
+		{"latest granted permit", []service.Permit{
+			permit("a", service.StatusApproved, -2*time.Hour, time.Time{}),
+			permit("b", service.StatusApproved, -time.Hour, time.Time{}),
+			permit("c", service.StatusRevoked, -time.Minute, time.Time{}),
+		}, "b"},
+		{"expired approval is not granted", []service.Permit{
+			permit("a", service.StatusApproved, -2*time.Hour, now.Add(-time.Minute)),
+			permit("b", service.StatusDenied, -time.Hour, time.Time{}),
+		}, "b"},
+	}
+
+	for _, tt := range tests {
+		got := service.EffectivePermit(tt.permits, now)
+		gotReqID := ""
+		if got != nil {
+			gotReqID = got.GetReqID()
+		}
+
+		if gotReqID != tt.want {
+			t.Errorf("%s: EffectivePermit() is of request %q, want %q", tt.name, gotReqID, tt.want)
+		}
+	}
+}
+
+func TestEffectiveUserStatuses(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	permits := []service.Permit{
+		&mongodb.BSON{UserID: "b", ReqID: "1", Status: service.StatusApproved, CreatedAt: now.Add(-2 * time.Hour)},
+		&mongodb.BSON{UserID: "a", ReqID: "1", Status: service.StatusDenied, CreatedAt: now.Add(-2 * time.Hour)},
+		&mongodb.BSON{UserID: "b", ReqID: "2", Status: service.StatusPending, CreatedAt: now.Add(-time.Hour)},
+		&mongodb.BSON{UserID: "a", ReqID: "2", Status: service.StatusPending, CreatedAt: now.Add(-time.Hour)},
+	}
+
+	got := service.EffectiveUserStatuses(permits, now)
+	want := []struct {
+		userID string
+		status string
+	}{
+		{"b", service.StatusApproved},
+		{"a", service.StatusPending},
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("EffectiveUserStatuses() returned %d statuses, want %d", len(got), len(want))
+	}
+
+	for i := range want {
+		if got[i].GetUserId() != want[i].userID || got[i].GetStatus() != want[i].status {
+			t.Errorf("EffectiveUserStatuses()[%d] = %s %s, want %s %s",
+				i, got[i].GetUserId(), got[i].GetStatus(), want[i].userID, want[i].status)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// outboxLease is the duration a claimed message is hidden from other dispatchers
+	// while it's being delivered, which is also the timeout of delivering it.
+	outboxLease = time.Minute
+
+	// outboxMinBackoff is the delay before the first retry of a failed delivery,
+	// the delay is doubled after every failed attempt.
+	outboxMinBackoff = time.Second
+)
+
+// Dispatcher delivers the messages of the outbox to the approval service,
+// retrying failed deliveries with an exponential backoff until they succeed.
+type Dispatcher struct {
+	controller Controller
+	deliver    func(ctx context.Context, message OutboxMessage) error
+	logger     *logrus.Logger
+	notify     chan struct{}
+}
+
+// newDispatcher creates a Dispatcher which delivers the messages stored by controller with deliver.
+func newDispatcher(
+	controller Controller,
+	deliver func(ctx context.Context, message OutboxMessage) error,
+	logger *logrus.Logger,
+) *Dispatcher {
+	return &Dispatcher{
+		controller: controller,
+		deliver:    deliver,
+		logger:     logger,
+		notify:     make(chan struct{}, 1),
+	}
+}
+
+// Notify wakes the dispatcher up to deliver newly stored messages without waiting for its interval.
+func (d *Dispatcher) Notify() {
+	select {
+	case d.notify <- struct{}{}:
+	default:
+	}
+}
+
+// Run is running an infinite loop that delivers all of the due messages once in interval,
+// or whenever Notify is called, until ctx is done.
+// A message that failed delivery is retried after a backoff of at most maxBackoff.
+func (d *Dispatcher) Run(ctx context.Context, interval time.Duration, maxBackoff time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		d.dispatchDue(ctx, maxBackoff)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-d.notify:
+		}
+	}
+}
+
+// dispatchDue delivers messages until there are no more messages that are due.
+func (d *Dispatcher) dispatchDue(ctx context.Context, maxBackoff time.Duration) {
+	for ctx.Err() == nil {
+		message, err := d.controller.ClaimOutboxMessage(ctx, time.Now(), outboxLease)
+		if status.Code(err) == codes.NotFound {
+			return
+		}
+
+		if err != nil {
+			d.logger.Errorf("failed claiming outbox message: %v", err)
+			return
+		}
+
+		d.dispatch(ctx, message, maxBackoff)
+	}
+}
+
+// dispatch delivers message, and removes it from the outbox on success,
+// otherwise it's rescheduled for another attempt.
+func (d *Dispatcher) dispatch(ctx context.Context, message OutboxMessage, maxBackoff time.Duration) {
+	deliverCtx, cancel := context.WithTimeout(ctx, outboxLease)
+	err := d.deliver(deliverCtx, message)
+	cancel()
+
+	if err != nil {
+		nextAttemptAt := time.Now().Add(backoff(message.Attempts, maxBackoff))
+		d.logger.Errorf(
+			"failed delivering approval request %s, attempt %d, retrying at %v: %v",
+			message.ReqID,
+			message.Attempts,
+			nextAttemptAt,
+			err,
+		)
+
+		if err := d.controller.RetryOutboxMessage(ctx, message.ID, nextAttemptAt, err.Error()); err != nil {
+			d.logger.Errorf("failed rescheduling outbox message %s: %v", message.ID, err)
+		}
+
+		return
+	}
+
+	if err := d.controller.DeleteOutboxMessage(ctx, message.ID); err != nil {
+		d.logger.Errorf("failed removing delivered outbox message %s: %v", message.ID, err)
+	}
+}
+
+// backoff returns the delay before the next delivery attempt of a message that
+// failed attempts times, doubling from outboxMinBackoff up to maxBackoff.
+func backoff(attempts int, maxBackoff time.Duration) time.Duration {
+	delay := outboxMinBackoff
+	for i := 1; i < attempts && delay < maxBackoff; i++ {
+		delay *= 2
+	}
+
+	if delay > maxBackoff {
+		return maxBackoff
+	}
+
+	return delay
+}
@@ -0,0 +1,29 @@
+package service
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		attempts   int
+		maxBackoff time.Duration
+		want       time.Duration
+	}{
+		{0, time.Minute, time.Second},
+		{1, time.Minute, time.Second},
+		{2, time.Minute, 2 * time.Second},
+		{3, time.Minute, 4 * time.Second},
+		{6, time.Minute, 32 * time.Second},
+		{7, time.Minute, time.Minute},
+		{1000, time.Minute, time.Minute},
+		{1, 500 * time.Millisecond, 500 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		if got := backoff(tt.attempts, tt.maxBackoff); got != tt.want {
+			t.Errorf("backoff(%d, %v) = %v, want %v", tt.attempts, tt.maxBackoff, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// invalidArgument returns a codes.InvalidArgument status error of field violating description,
+// detailed with an errdetails.BadRequest so clients can tell which field is invalid.
+func invalidArgument(field string, description string) error {
+	return badRequest(&errdetails.BadRequest_FieldViolation{Field: field, Description: description})
+}
+
+// badRequest returns a codes.InvalidArgument status error detailed with an errdetails.BadRequest
+// of violations, its message is the descriptions of violations.
+func badRequest(violations ...*errdetails.BadRequest_FieldViolation) error {
+	descriptions := make([]string, 0, len(violations))
+	for _, violation := range violations {
+		descriptions = append(descriptions, violation.GetDescription())
+	}
+
+	st := status.New(codes.InvalidArgument, strings.Join(descriptions, "; "))
+	detailed, err := st.WithDetails(&errdetails.BadRequest{FieldViolations: violations})
+	if err != nil {
+		return st.Err()
+	}
+
+	return detailed.Err()
+}
+
+// internalError returns a codes.Internal status error of msg caused by err,
+// unless err is already a status error, in which case it is returned as is.
+func internalError(err error, msg string) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	return status.Errorf(codes.Internal, "%s: %v", msg, err)
+}
+
+// unavailableError returns a codes.Unavailable status error of failing to reach the dependency
+// service, caused by err.
+func unavailableError(err error, service string) error {
+	return status.Error(codes.Unavailable, fmt.Sprintf("%s is unavailable: %v", service, err))
+}
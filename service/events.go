@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	// EventPermitCreated is the type of the event of a permit created pending approval.
+	EventPermitCreated = "permit.created"
+
+	// eventPermitPrefix is the prefix of the types of events of permit status changes,
+	// followed by the new status, i.e. "permit.approved".
+	eventPermitPrefix = "permit."
+)
+
+// PermitEvent is an event of the lifecycle of the permit of a user to a file.
+type PermitEvent struct {
+	Type   string    `json:"type"`
+	ReqID  string    `json:"reqID"`
+	FileID string    `json:"fileID"`
+	UserID string    `json:"userID"`
+	Status string    `json:"status"`
+	Actor  string    `json:"actor,omitempty"`
+	Reason string    `json:"reason,omitempty"`
+	Time   time.Time `json:"time"`
+}
+
+// EventPublisher is an interface for publishing permit events to the services that react to them.
+type EventPublisher interface {
+	Publish(ctx context.Context, events ...PermitEvent) error
+	Close() error
+}
+
+// NoopPublisher is an EventPublisher that discards all events.
+type NoopPublisher struct{}
+
+// Publish discards events.
+func (NoopPublisher) Publish(ctx context.Context, events ...PermitEvent) error {
+	return nil
+}
+
+// Close does nothing.
+func (NoopPublisher) Close() error {
+	return nil
+}
+
+// EventType returns the type of the event of a permit changing to status.
+func EventType(status string) string {
+	if status == StatusPending {
+		return EventPermitCreated
+	}
+
+	return eventPermitPrefix + status
+}
+
+// newPermitEvent returns the event of change to the permit of userID to fileID by the request reqID.
+func newPermitEvent(reqID string, fileID string, userID string, change StatusChange) PermitEvent {
+	return PermitEvent{
+		Type:   EventType(change.Status),
+		ReqID:  reqID,
+		FileID: fileID,
+		UserID: userID,
+		Status: change.Status,
+		Actor:  change.Actor,
+		Reason: change.Reason,
+		Time:   change.Time,
+	}
+}
+
+// changedPermitEvents returns the events of change to each of permits that it changes the status of.
+func changedPermitEvents(permits []Permit, change StatusChange) []PermitEvent {
+	events := make([]PermitEvent, 0, len(permits))
+	for _, permit := range permits {
+		if permit.GetStatus() != change.Status && CanTransition(permit.GetStatus(), change.Status) {
+			events = append(events, newPermitEvent(permit.GetReqID(), permit.GetFileID(), permit.GetUserID(), change))
+		}
+	}
+
+	return events
+}
+
+// publish publishes events with the service's publisher, failures are logged since the
+// changes the events describe are already stored.
+func (s Service) publish(ctx context.Context, events ...PermitEvent) {
+	if len(events) == 0 {
+		return
+	}
+
+	if err := s.publisher.Publish(ctx, events...); err != nil {
+		s.logger.Errorf("failed publishing %d permit events: %v", len(events), err)
+	}
+}
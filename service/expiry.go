@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+const (
+	// SystemActor is the actor of status changes made by the service itself.
+	SystemActor = "permit-service"
+
+	// expiredReason is the reason of the status change of permits whose validity has ended.
+	expiredReason = "permit validity ended"
+
+	// MaxPermitValidity is the longest time permits may be valid for from their creation.
+	MaxPermitValidity = 10 * 365 * 24 * time.Hour
+)
+
+// permitExpiry returns the time permits created at now expire at, given either the time
+// expiresAt in unix milliseconds or a ttl in seconds, or the zero time if neither is given.
+// Returns a codes.InvalidArgument status error if both are given, if the permits would already be expired,
+// or if they would be valid for longer than MaxPermitValidity.
+func permitExpiry(now time.Time, expiresAt int64, ttl int64) (time.Time, error) {
+	if expiresAt != 0 && ttl != 0 {
+		return time.Time{}, invalidArgument("ttl", "only one of expiresAt and ttl may be given")
+	}
+
+	if ttl < 0 {
+		return time.Time{}, invalidArgument("ttl", "ttl must be positive")
+	}
+
+	// The bounds are checked before converting to a time, which would overflow for larger values.
+	maxTTL := int64(MaxPermitValidity / time.Second)
+	if ttl > maxTTL {
+		return time.Time{}, invalidArgument("ttl", fmt.Sprintf("ttl must be at most %d seconds", maxTTL))
+	}
+
+	if ttl > 0 {
+		return now.Add(time.Duration(ttl) * time.Second), nil
+	}
+
+	if expiresAt > UnixMillis(now.Add(MaxPermitValidity)) {
+		msg := fmt.Sprintf("expiresAt must be at most %v from now", MaxPermitValidity)
+		return time.Time{}, invalidArgument("expiresAt", msg)
+	}
+
+	expiry := FromUnixMillis(expiresAt)
+	if !expiry.IsZero() && !expiry.After(now) {
+		return time.Time{}, invalidArgument("expiresAt", "expiresAt must be in the future")
+	}
+
+	return expiry, nil
+}
+
+// SweepExpiredPermits is running an infinite loop that updates the status of the permits whose
+// validity has ended to expired once in interval, publishing an event of each expired permit,
+// until ctx is done.
+func (s Service) SweepExpiredPermits(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		now := time.Now()
+		change := StatusChange{
+			Status: StatusExpired,
+			Actor:  SystemActor,
+			Reason: expiredReason,
+			Time:   now,
+		}
+
+		expired, err := s.controller.ExpirePermits(ctx, StatusesTransitioningTo(StatusExpired), change)
+		if err != nil {
+			s.logger.Errorf("failed expiring permits: %v", err)
+		} else if len(expired) > 0 {
+			s.logger.Infof("expired %d permits", len(expired))
+			s.publish(ctx, changedPermitEvents(expired, change)...)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package service
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestPermitExpiry(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	maxTTL := int64(MaxPermitValidity / time.Second)
+
+	tests := []struct {
+		name      string
+		expiresAt int64
+		ttl       int64
+		want      time.Time
+		wantErr   bool
+	}{
+		{"no expiry", 0, 0, time.Time{}, false},
+		{"ttl", 0, 3600, now.Add(time.Hour), false},
+		{"max ttl", 0, maxTTL, now.Add(MaxPermitValidity), false},
+		{"ttl above max", 0, maxTTL + 1, time.Time{}, true},
+		{"overflowing ttl", 0, math.MaxInt64, time.Time{}, true},
+		{"negative ttl", 0, -1, time.Time{}, true},
+		{"expiresAt", UnixMillis(now.Add(time.Minute)), 0, now.Add(time.Minute), false},
+		{"max expiresAt", UnixMillis(now.Add(MaxPermitValidity)), 0, now.Add(MaxPermitValidity), false},
+		{"expiresAt above max", UnixMillis(now.Add(MaxPermitValidity)) + 1, 0, time.Time{}, true},
+		{"overflowing expiresAt", math.MaxInt64, 0, time.Time{}, true},
+		{"expiresAt now", UnixMillis(now), 0, time.Time{}, true},
+		{"expiresAt in the past", UnixMillis(now.Add(-time.Minute)), 0, time.Time{}, true},
+		{"both", UnixMillis(now.Add(time.Minute)), 60, time.Time{}, true},
+	}
+
+	for _, tt := range tests {
+		got, err := permitExpiry(now, tt.expiresAt, tt.ttl)
+		if (err != nil) != tt.wantErr || !got.Equal(tt.want) {
+			t.Errorf("%s: permitExpiry() = %v, %v, want %v, error %v", tt.name, got, err, tt.want, tt.wantErr)
+		}
+
+		if tt.wantErr && status.Code(err) != codes.InvalidArgument {
+			t.Errorf("%s: permitExpiry() error = %v, want code %v", tt.name, err, codes.InvalidArgument)
+		}
+	}
+}
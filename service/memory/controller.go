@@ -0,0 +1,619 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	pb "github.com/meateam/permit-service/proto"
+	"github.com/meateam/permit-service/service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Controller is the permisison service business logic implementation using an in-memory Store.
+// Its writes of several objects run in transactions of the Store, just like the mongodb controller's.
+type Controller struct {
+	store *Store
+}
+
+var _ service.Controller = Controller{}
+
+// NewController returns a new controller with an empty store.
+func NewController() Controller {
+	return Controller{store: NewStore()}
+}
+
+// HealthCheck runs store's healthcheck and returns true if healthy, otherwise returns false
+// and any error if occured.
+func (c Controller) HealthCheck(ctx context.Context) (bool, error) {
+	return c.store.HealthCheck(ctx)
+}
+
+// CreatePermits stores request and creates the permits of its file to each of its users with the
+// initial status change, and stores message in the outbox, all in a single transaction, so either
+// all of them are stored or none of them are. Returns the created permits, or a codes.AlreadyExists
+// status error if a request with the same reqID, or of the same sharer with the same idempotency key,
+// already exists.
+func (c Controller) CreatePermits(
+	ctx context.Context,
+	request service.ApprovalReqType,
+	change service.StatusChange,
+	message service.OutboxMessage,
+) ([]service.Permit, error) {
+	permits := make([]service.Permit, 0, len(request.To))
+	for _, user := range request.To {
+		permit := &Permit{
+			FileID:    request.FileID,
+			ReqID:     request.ID,
+			UserID:    user.ID,
+			Status:    change.Status,
+			CreatedAt: change.Time,
+			UpdatedAt: change.Time,
+			ExpiresAt: service.FromUnixMillis(request.ExpiresAt),
+		}
+
+		if err := permit.AppendHistory(change); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+
+		permits = append(permits, permit)
+	}
+
+	stored := newRequest(request, message.CreatedAt)
+
+	err := c.store.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := c.store.CreateRequest(ctx, stored); err != nil {
+			return err
+		}
+
+		if err := c.store.CreateMany(ctx, permits); err != nil {
+			return err
+		}
+
+		_, err := c.store.CreateOutboxMessage(ctx, message)
+		return err
+	})
+
+	if err != nil {
+		return nil, toStatusError(err, "failed creating permits")
+	}
+
+	return permits, nil
+}
+
+// GetPermitsByFileID returns the status of the effective permit of each user associated with the fileID,
+// which is empty if the file has no permits.
+func (c Controller) GetPermitsByFileID(ctx context.Context, fileID string) ([]*pb.UserStatus, error) {
+	permits, err := c.store.GetAll(ctx, Filter(func(permit service.Permit) bool {
+		return permit.GetFileID() == fileID
+	}))
+	if err != nil {
+		return nil, toStatusError(err, "failed retrieving permits")
+	}
+
+	return service.EffectiveUserStatuses(permits, time.Now()), nil
+}
+
+// GetPermit returns the effective permit of userID to fileID out of the permits of all of their requests,
+// if no such permit exists it returns a codes.NotFound status error.
+func (c Controller) GetPermit(ctx context.Context, fileID string, userID string) (service.Permit, error) {
+	permits, err := c.store.GetAll(ctx, Filter(func(permit service.Permit) bool {
+		return permit.GetFileID() == fileID && permit.GetUserID() == userID
+	}))
+	if err != nil {
+		return nil, toStatusError(err, "failed retrieving permit")
+	}
+
+	if len(permits) == 0 {
+		return nil, status.Errorf(codes.NotFound, "permit of user %s to file %s not found", userID, fileID)
+	}
+
+	return service.EffectivePermit(permits, time.Now()), nil
+}
+
+// GetPermitsByKeys returns the effective permits of the pairs of keys, mapped by their keys.
+// Keys without a permit are missing from the returned map.
+func (c Controller) GetPermitsByKeys(ctx context.Context, keys []service.PermitKey) (map[service.PermitKey]service.Permit, error) {
+	wanted := make(map[service.PermitKey]bool, len(keys))
+	for _, key := range keys {
+		wanted[key] = true
+	}
+
+	permits, err := c.store.GetAll(ctx, Filter(func(permit service.Permit) bool {
+		return wanted[service.PermitKey{FileID: permit.GetFileID(), UserID: permit.GetUserID()}]
+	}))
+	if err != nil {
+		return nil, toStatusError(err, "failed retrieving permits")
+	}
+
+	return service.EffectivePermitsByKey(permits, time.Now()), nil
+}
+
+// ListPermitsByUser returns a page of up to pageSize permits of userID whose status is one of statuses,
+// or of any status if statuses is empty, starting after pageToken.
+// Returns the permits and the token of the next page, which is empty if there are no more pages.
+func (c Controller) ListPermitsByUser(
+	ctx context.Context,
+	userID string,
+	statuses []string,
+	pageSize int64,
+	pageToken string,
+) ([]service.Permit, string, error) {
+	filter := Filter(func(permit service.Permit) bool {
+		return permit.GetUserID() == userID
+	})
+
+	if len(statuses) > 0 {
+		filter = withStatuses(filter, statuses)
+	}
+
+	if !validPageToken(pageToken) {
+		return nil, "", service.ErrInvalidPageToken
+	}
+
+	// One extra permit is fetched to tell whether there is a next page.
+	permits, err := c.store.GetPage(ctx, func(permit service.Permit) bool {
+		return filter(permit) && beforePageToken(permit.GetID(), pageToken)
+	}, pageSize+1)
+	if err != nil {
+		return nil, "", toStatusError(err, "failed listing permits")
+	}
+
+	hasMore := int64(len(permits)) > pageSize
+	if hasMore {
+		permits = permits[:pageSize]
+	}
+
+	lastID := ""
+	if len(permits) > 0 {
+		lastID = permits[len(permits)-1].GetID()
+	}
+
+	return permits, service.NextPageToken(lastID, hasMore), nil
+}
+
+// ListPermitRequestsBySharer returns a page of up to pageSize permit requests of sharerID along with
+// their permits, starting after pageToken. If statuses is not empty, only requests with a permit
+// whose status is one of statuses are listed.
+// Returns the requests and the token of the next page, which is empty if there are no more pages.
+func (c Controller) ListPermitRequestsBySharer(
+	ctx context.Context,
+	sharerID string,
+	statuses []string,
+	pageSize int64,
+	pageToken string,
+) ([]service.RequestPermits, string, error) {
+	filter := RequestFilter(func(request service.PermitRequest) bool {
+		return request.GetSharerID() == sharerID
+	})
+
+	return c.listRequests(ctx, filter, statuses, pageSize, pageToken)
+}
+
+// ListPendingApprovals returns a page of up to pageSize permit requests that approverID is an approver of
+// and that have pending permits, along with their permits, starting after pageToken.
+// Returns the requests and the token of the next page, which is empty if there are no more pages.
+func (c Controller) ListPendingApprovals(
+	ctx context.Context,
+	approverID string,
+	pageSize int64,
+	pageToken string,
+) ([]service.RequestPermits, string, error) {
+	filter := RequestFilter(func(request service.PermitRequest) bool {
+		for _, approver := range request.GetApprovers() {
+			if approver == approverID {
+				return true
+			}
+		}
+
+		return false
+	})
+
+	return c.listRequests(ctx, filter, []string{service.StatusPending}, pageSize, pageToken)
+}
+
+// listRequests returns a page of up to pageSize permit requests that match filter, along with their
+// permits, starting after pageToken. If statuses is not empty, only requests with a permit whose
+// status is one of statuses are listed.
+func (c Controller) listRequests(
+	ctx context.Context,
+	filter RequestFilter,
+	statuses []string,
+	pageSize int64,
+	pageToken string,
+) ([]service.RequestPermits, string, error) {
+	if !validPageToken(pageToken) {
+		return nil, "", service.ErrInvalidPageToken
+	}
+
+	// One extra request is fetched to tell whether there is a next page.
+	requests, err := c.store.GetRequestsPage(ctx, func(request service.PermitRequest) bool {
+		return filter(request) && beforePageToken(request.GetID(), pageToken)
+	}, statuses, pageSize+1)
+	if err != nil {
+		return nil, "", toStatusError(err, "failed listing requests")
+	}
+
+	hasMore := int64(len(requests)) > pageSize
+	if hasMore {
+		requests = requests[:pageSize]
+	}
+
+	lastID := ""
+	if len(requests) > 0 {
+		lastID = requests[len(requests)-1].Request.GetID()
+	}
+
+	return requests, service.NextPageToken(lastID, hasMore), nil
+}
+
+// GetPermitRequest returns the permit request reqID,
+// if no such request exists it returns a codes.NotFound status error.
+func (c Controller) GetPermitRequest(ctx context.Context, reqID string) (service.PermitRequest, error) {
+	request, err := c.store.GetRequest(ctx, func(request service.PermitRequest) bool {
+		return request.GetReqID() == reqID
+	})
+	if err == ErrNotFound {
+		return nil, status.Errorf(codes.NotFound, "request %s not found", reqID)
+	}
+
+	if err != nil {
+		return nil, toStatusError(err, "failed retrieving request")
+	}
+
+	return request, nil
+}
+
+// GetPermitRequestByIdempotencyKey returns the permit request of sharerID created with idempotencyKey,
+// if no such request exists it returns a codes.NotFound status error.
+func (c Controller) GetPermitRequestByIdempotencyKey(
+	ctx context.Context,
+	sharerID string,
+	idempotencyKey string,
+) (service.PermitRequest, error) {
+	request, err := c.store.GetRequest(ctx, func(request service.PermitRequest) bool {
+		return request.GetSharerID() == sharerID && request.GetIdempotencyKey() == idempotencyKey
+	})
+	if err == ErrNotFound {
+		return nil, status.Errorf(codes.NotFound, "no request of %s has idempotency key %s", sharerID, idempotencyKey)
+	}
+
+	if err != nil {
+		return nil, toStatusError(err, "failed retrieving request")
+	}
+
+	return request, nil
+}
+
+// GetPermitsByReqID returns the permits created by the request reqID,
+// if the request has no permits it returns a codes.NotFound status error.
+func (c Controller) GetPermitsByReqID(ctx context.Context, reqID string) ([]service.Permit, error) {
+	permits, err := c.store.GetAll(ctx, reqIDFilter(reqID))
+	if err != nil {
+		return nil, toStatusError(err, "failed retrieving permits")
+	}
+
+	if len(permits) == 0 {
+		return nil, status.Errorf(codes.NotFound, "request %s not found", reqID)
+	}
+
+	return permits, nil
+}
+
+// UpdatePermitStatus updates the permits of the request reqID whose status is one of
+// fromStatuses by change, returns true if any permit was updated, and false otherwise.
+func (c Controller) UpdatePermitStatus(
+	ctx context.Context,
+	reqID string,
+	fromStatuses []string,
+	change service.StatusChange,
+) (bool, error) {
+	updated, err := c.store.UpdateStatus(ctx, withStatuses(reqIDFilter(reqID), fromStatuses), change)
+	if err != nil {
+		return false, toStatusError(err, "failed updating permit status")
+	}
+
+	return updated > 0, nil
+}
+
+// UpdateUserPermitStatuses applies each of updates to the permit of its user in the request reqID,
+// all in a single transaction. Returns the number of updated permits.
+func (c Controller) UpdateUserPermitStatuses(
+	ctx context.Context,
+	reqID string,
+	updates []service.UserStatusUpdate,
+) (int64, error) {
+	var updated int64
+	err := c.store.WithTransaction(ctx, func(ctx context.Context) error {
+		updated = 0
+		for _, update := range updates {
+			userID := update.UserID
+			filter := Filter(func(permit service.Permit) bool {
+				return permit.GetReqID() == reqID && permit.GetUserID() == userID
+			})
+
+			count, err := c.store.UpdateStatus(ctx, withStatuses(filter, update.FromStatuses), update.Change)
+			if err != nil {
+				return err
+			}
+
+			updated += count
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return 0, toStatusError(err, "failed updating permit statuses")
+	}
+
+	return updated, nil
+}
+
+// RevokePermit updates the permits of userID to fileID, by all of their requests, whose status is one
+// of fromStatuses by change in a single transaction, and returns the permits that were revoked as they
+// were before change.
+func (c Controller) RevokePermit(
+	ctx context.Context,
+	fileID string,
+	userID string,
+	fromStatuses []string,
+	change service.StatusChange,
+) ([]service.Permit, error) {
+	filter := withStatuses(Filter(func(permit service.Permit) bool {
+		return permit.GetFileID() == fileID && permit.GetUserID() == userID
+	}), fromStatuses)
+
+	revoked, err := c.updateStatus(ctx, filter, change)
+	if err != nil {
+		return nil, toStatusError(err, "failed revoking permit")
+	}
+
+	return revoked, nil
+}
+
+// CancelPermits updates the permits of the request reqID whose status is one of fromStatuses
+// by change, and notifies the approval service of the cancellation with message, all in a single
+// transaction. An approval request that was not delivered yet is discarded, and message is delayed
+// until any attempt of delivering it that may still be in flight is over, see service.CancelAfter.
+// Returns the number of cancelled permits.
+func (c Controller) CancelPermits(
+	ctx context.Context,
+	reqID string,
+	fromStatuses []string,
+	change service.StatusChange,
+	message service.OutboxMessage,
+) (int64, error) {
+	var cancelled int64
+	err := c.store.WithTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		cancelled, err = c.store.UpdateStatus(ctx, withStatuses(reqIDFilter(reqID), fromStatuses), change)
+		if err != nil || cancelled == 0 {
+			return err
+		}
+
+		undelivered, err := c.store.GetOutboxMessages(ctx, undeliveredFilter(reqID))
+		if err != nil {
+			return err
+		}
+
+		if _, err := c.store.DeleteOutboxMessages(ctx, undeliveredFilter(reqID)); err != nil {
+			return err
+		}
+
+		_, err = c.store.CreateOutboxMessage(ctx, service.CancelAfter(message, undelivered))
+		return err
+	})
+
+	if err != nil {
+		return 0, toStatusError(err, "failed cancelling permits")
+	}
+
+	return cancelled, nil
+}
+
+// CompleteApprovalRequest records that the approval request of reqID was submitted to the approval
+// service, which gave it approvalID if it's not empty, and removes it from the outbox, in a single transaction.
+func (c Controller) CompleteApprovalRequest(ctx context.Context, reqID string, approvalID string) error {
+	err := c.store.WithTransaction(ctx, func(ctx context.Context) error {
+		err := c.store.SetRequestSubmission(ctx, reqID, service.SubmissionSubmitted, approvalID, "")
+		if err != nil {
+			return err
+		}
+
+		_, err = c.store.DeleteOutboxMessages(ctx, undeliveredFilter(reqID))
+		return err
+	})
+
+	if err == ErrNotFound {
+		return status.Errorf(codes.NotFound, "request %s not found", reqID)
+	}
+
+	if err != nil {
+		return toStatusError(err, "failed completing approval request")
+	}
+
+	return nil
+}
+
+// FailApprovalRequest updates the permits of the request reqID whose status is one of fromStatuses
+// by change, after the approval service rejected their approval request with the reason of change,
+// records the rejection and removes the approval request from the outbox, in a single transaction.
+// Returns the number of updated permits.
+func (c Controller) FailApprovalRequest(
+	ctx context.Context,
+	reqID string,
+	fromStatuses []string,
+	change service.StatusChange,
+) (int64, error) {
+	var failed int64
+	err := c.store.WithTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		failed, err = c.store.UpdateStatus(ctx, withStatuses(reqIDFilter(reqID), fromStatuses), change)
+		if err != nil {
+			return err
+		}
+
+		err = c.store.SetRequestSubmission(ctx, reqID, service.SubmissionRejected, "", change.Reason)
+		if err != nil {
+			return err
+		}
+
+		_, err = c.store.DeleteOutboxMessages(ctx, undeliveredFilter(reqID))
+		return err
+	})
+
+	if err == ErrNotFound {
+		return 0, status.Errorf(codes.NotFound, "request %s not found", reqID)
+	}
+
+	if err != nil {
+		return 0, toStatusError(err, "failed updating permits of rejected approval request")
+	}
+
+	return failed, nil
+}
+
+// WatchPermits calls send with each permit that matches filter whenever it's created or updated,
+// until ctx is done or send returns an error. A permit written several times before it's sent is sent
+// once with its latest values.
+func (c Controller) WatchPermits(ctx context.Context, filter service.WatchFilter, send func(service.Permit) error) error {
+	return c.store.Watch(ctx, watchFilter(filter), send)
+}
+
+// ExpirePermits updates the permits whose status is one of fromStatuses and whose validity
+// has ended by the time of change, by change, in a single transaction.
+// Returns the permits that were expired as they were before change.
+func (c Controller) ExpirePermits(
+	ctx context.Context,
+	fromStatuses []string,
+	change service.StatusChange,
+) ([]service.Permit, error) {
+	filter := withStatuses(Filter(func(permit service.Permit) bool {
+		expiresAt := permit.GetExpiresAt()
+		return !expiresAt.IsZero() && !expiresAt.After(change.Time)
+	}), fromStatuses)
+
+	expired, err := c.updateStatus(ctx, filter, change)
+	if err != nil {
+		return nil, toStatusError(err, "failed expiring permits")
+	}
+
+	return expired, nil
+}
+
+// ClaimOutboxMessage claims the outbox message that is due the earliest at now for lease,
+// if there are no due messages it returns a codes.NotFound status error.
+func (c Controller) ClaimOutboxMessage(ctx context.Context, now time.Time, lease time.Duration) (service.OutboxMessage, error) {
+	message, err := c.store.ClaimOutboxMessage(ctx, now, lease)
+	if err == ErrNotFound {
+		return service.OutboxMessage{}, status.Error(codes.NotFound, "no due outbox messages")
+	}
+
+	if err != nil {
+		return service.OutboxMessage{}, toStatusError(err, "failed claiming outbox message")
+	}
+
+	return message, nil
+}
+
+// RetryOutboxMessage schedules another delivery attempt of the outbox message id at nextAttemptAt.
+func (c Controller) RetryOutboxMessage(ctx context.Context, id string, nextAttemptAt time.Time, lastError string) error {
+	return c.store.RetryOutboxMessage(ctx, id, nextAttemptAt, lastError)
+}
+
+// DeleteOutboxMessage removes the delivered outbox message id.
+func (c Controller) DeleteOutboxMessage(ctx context.Context, id string) error {
+	return c.store.DeleteOutboxMessage(ctx, id)
+}
+
+// updateStatus updates the permits that match filter by change in a single transaction,
+// and returns the updated permits as they were before change.
+func (c Controller) updateStatus(ctx context.Context, filter Filter, change service.StatusChange) ([]service.Permit, error) {
+	var updated []service.Permit
+	err := c.store.WithTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		updated, err = c.store.GetAll(ctx, filter)
+		if err != nil || len(updated) == 0 {
+			return err
+		}
+
+		_, err = c.store.UpdateStatus(ctx, filter, change)
+		return err
+	})
+
+	return updated, err
+}
+
+// reqIDFilter returns the filter of the permits of the request reqID.
+func reqIDFilter(reqID string) Filter {
+	return func(permit service.Permit) bool {
+		return permit.GetReqID() == reqID
+	}
+}
+
+// withStatuses returns filter narrowed down to permits whose status is one of statuses.
+func withStatuses(filter Filter, statuses []string) Filter {
+	return func(permit service.Permit) bool {
+		return filter(permit) && hasStatus(permit, statuses)
+	}
+}
+
+// undeliveredFilter returns the filter of the outbox messages of the approval request of reqID
+// which were not delivered yet.
+func undeliveredFilter(reqID string) OutboxFilter {
+	return func(message service.OutboxMessage) bool {
+		return message.ReqID == reqID && (message.Kind == service.OutboxKindCreate || message.Kind == "")
+	}
+}
+
+// watchFilter returns the Filter of the permits that match each of the non-empty fields of filter.
+func watchFilter(filter service.WatchFilter) Filter {
+	return func(permit service.Permit) bool {
+		return (filter.FileID == "" || permit.GetFileID() == filter.FileID) &&
+			(filter.UserID == "" || permit.GetUserID() == filter.UserID) &&
+			(filter.ReqID == "" || permit.GetReqID() == filter.ReqID)
+	}
+}
+
+// hasStatus returns true if the status of permit is one of statuses.
+func hasStatus(permit service.Permit, statuses []string) bool {
+	for _, status := range statuses {
+		if permit.GetStatus() == status {
+			return true
+		}
+	}
+
+	return false
+}
+
+// anyHasStatus returns true if the status of any of permits is one of statuses.
+func anyHasStatus(permits []*Permit, statuses []string) bool {
+	for _, permit := range permits {
+		if hasStatus(permit, statuses) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// toStatusError converts err returned from the store to a status error with msg prefixed to its message,
+// ErrDuplicate is converted to codes.AlreadyExists.
+func toStatusError(err error, msg string) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	code := codes.Internal
+	switch err {
+	case ErrDuplicate:
+		code = codes.AlreadyExists
+	case context.DeadlineExceeded:
+		code = codes.DeadlineExceeded
+	case context.Canceled:
+		code = codes.Canceled
+	}
+
+	return status.Errorf(code, "%s: %v", msg, err)
+}
@@ -0,0 +1,204 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/meateam/permit-service/service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// createPermits creates the permits of the request reqID by sharerID to userIDs, with idempotencyKey.
+func createPermits(c Controller, reqID string, idempotencyKey string, userIDs ...string) error {
+	now := time.Now()
+	users := make([]service.UserType, 0, len(userIDs))
+	for _, userID := range userIDs {
+		users = append(users, service.UserType{ID: userID})
+	}
+
+	request := service.ApprovalReqType{
+		ID:             reqID,
+		From:           "sharer",
+		To:             users,
+		FileID:         "file",
+		IdempotencyKey: idempotencyKey,
+	}
+
+	message := service.OutboxMessage{
+		Kind:          service.OutboxKindCreate,
+		ReqID:         reqID,
+		Request:       request,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+	}
+
+	_, err := c.CreatePermits(context.Background(), request, service.StatusChange{Status: service.StatusPending, Time: now}, message)
+	return err
+}
+
+func TestCreatePermitsRollback(t *testing.T) {
+	c := NewController()
+	ctx := context.Background()
+
+	if err := createPermits(c, "1", "key", "a"); err != nil {
+		t.Fatalf("CreatePermits() failed: %v", err)
+	}
+
+	// The request fails on its idempotency key, and none of its objects are stored.
+	if err := createPermits(c, "2", "key", "b"); status.Code(err) != codes.AlreadyExists {
+		t.Fatalf("CreatePermits() with a used idempotency key error = %v, want %v", err, codes.AlreadyExists)
+	}
+
+	if _, err := c.GetPermitsByReqID(ctx, "2"); status.Code(err) != codes.NotFound {
+		t.Errorf("GetPermitsByReqID() of the failed request error = %v, want %v", err, codes.NotFound)
+	}
+
+	messages, err := c.store.GetOutboxMessages(ctx, nil)
+	if err != nil {
+		t.Fatalf("GetOutboxMessages() failed: %v", err)
+	}
+
+	if len(messages) != 1 || messages[0].ReqID != "1" {
+		t.Errorf("outbox = %v, want the message of request 1 only", messages)
+	}
+}
+
+func TestListPermitsByUserPages(t *testing.T) {
+	c := NewController()
+	ctx := context.Background()
+
+	for _, reqID := range []string{"1", "2", "3"} {
+		if err := createPermits(c, reqID, "", "a"); err != nil {
+			t.Fatalf("CreatePermits() failed: %v", err)
+		}
+	}
+
+	reqIDs := []string{}
+	pageToken := ""
+	for pages := 0; pages == 0 || pageToken != ""; pages++ {
+		if pages > 3 {
+			t.Fatalf("ListPermitsByUser() didn't end after %d pages", pages)
+		}
+
+		permits, next, err := c.ListPermitsByUser(ctx, "a", nil, 2, pageToken)
+		if err != nil {
+			t.Fatalf("ListPermitsByUser() failed: %v", err)
+		}
+
+		for _, permit := range permits {
+			reqIDs = append(reqIDs, permit.GetReqID())
+		}
+
+		pageToken = next
+	}
+
+	want := []string{"3", "2", "1"}
+	if len(reqIDs) != len(want) {
+		t.Fatalf("ListPermitsByUser() listed requests %v, want %v", reqIDs, want)
+	}
+
+	for i := range want {
+		if reqIDs[i] != want[i] {
+			t.Errorf("ListPermitsByUser() listed requests %v, want %v", reqIDs, want)
+			break
+		}
+	}
+
+	if _, _, err := c.ListPermitsByUser(ctx, "a", nil, 2, "invalid"); err != service.ErrInvalidPageToken {
+		t.Errorf("ListPermitsByUser() with an invalid token error = %v, want %v", err, service.ErrInvalidPageToken)
+	}
+}
+
+func TestWithTransactionRollback(t *testing.T) {
+	c := NewController()
+	ctx := context.Background()
+
+	if err := createPermits(c, "1", "", "a"); err != nil {
+		t.Fatalf("CreatePermits() failed: %v", err)
+	}
+
+	failed := errors.New("failed")
+	err := c.store.WithTransaction(ctx, func(ctx context.Context) error {
+		change := service.StatusChange{Status: service.StatusApproved, Time: time.Now()}
+		if _, err := c.store.UpdateStatus(ctx, reqIDFilter("1"), change); err != nil {
+			return err
+		}
+
+		if _, err := c.store.DeleteOutboxMessages(ctx, undeliveredFilter("1")); err != nil {
+			return err
+		}
+
+		return failed
+	})
+	if err != failed {
+		t.Fatalf("WithTransaction() error = %v, want %v", err, failed)
+	}
+
+	permits, err := c.GetPermitsByReqID(ctx, "1")
+	if err != nil {
+		t.Fatalf("GetPermitsByReqID() failed: %v", err)
+	}
+
+	if permits[0].GetStatus() != service.StatusPending || len(permits[0].GetHistory()) != 1 {
+		t.Errorf("permit status = %s with %d changes after rollback, want pending with 1 change",
+			permits[0].GetStatus(), len(permits[0].GetHistory()))
+	}
+
+	messages, err := c.store.GetOutboxMessages(ctx, nil)
+	if err != nil {
+		t.Fatalf("GetOutboxMessages() failed: %v", err)
+	}
+
+	if len(messages) != 1 {
+		t.Errorf("outbox has %d messages after rollback, want 1", len(messages))
+	}
+}
+
+func TestRevokePermit(t *testing.T) {
+	c := NewController()
+	ctx := context.Background()
+
+	if statuses, err := c.GetPermitsByFileID(ctx, "file"); err != nil || len(statuses) != 0 {
+		t.Fatalf("GetPermitsByFileID() of a file with no permits = %v, %v, want no statuses", statuses, err)
+	}
+
+	for _, reqID := range []string{"1", "2"} {
+		if err := createPermits(c, reqID, "", "a"); err != nil {
+			t.Fatalf("CreatePermits() failed: %v", err)
+		}
+	}
+
+	change := service.StatusChange{Status: service.StatusRevoked, Actor: "sharer", Time: time.Now()}
+	revoked, err := c.RevokePermit(ctx, "file", "a", []string{service.StatusPending}, change)
+	if err != nil {
+		t.Fatalf("RevokePermit() failed: %v", err)
+	}
+
+	// The permits of every request are revoked, and returned as they were before they were revoked.
+	if len(revoked) != 2 {
+		t.Fatalf("RevokePermit() revoked %d permits, want 2", len(revoked))
+	}
+
+	for _, permit := range revoked {
+		if permit.GetStatus() != service.StatusPending {
+			t.Errorf("RevokePermit() returned a permit of request %s with status %s, want %s",
+				permit.GetReqID(), permit.GetStatus(), service.StatusPending)
+		}
+	}
+
+	statuses, err := c.GetPermitsByFileID(ctx, "file")
+	if err != nil {
+		t.Fatalf("GetPermitsByFileID() failed: %v", err)
+	}
+
+	if len(statuses) != 1 || statuses[0].GetStatus() != service.StatusRevoked {
+		t.Errorf("GetPermitsByFileID() = %v, want a single revoked user", statuses)
+	}
+
+	if revoked, err := c.RevokePermit(ctx, "file", "a", []string{service.StatusPending}, change); err != nil || len(revoked) != 0 {
+		t.Errorf("RevokePermit() of revoked permits = %v, %v, want no permits", revoked, err)
+	}
+}
@@ -0,0 +1,26 @@
+package memory
+
+// validPageToken returns true if pageToken is empty or an ID given by a Store.
+func validPageToken(pageToken string) bool {
+	if pageToken == "" {
+		return true
+	}
+
+	if len(pageToken) != len(formatID(0)) {
+		return false
+	}
+
+	for _, r := range pageToken {
+		if !('0' <= r && r <= '9' || 'a' <= r && r <= 'f') {
+			return false
+		}
+	}
+
+	return true
+}
+
+// beforePageToken returns true if id is on a page after the page token pageToken,
+// pages are ordered from the newest object to the oldest.
+func beforePageToken(id string, pageToken string) bool {
+	return pageToken == "" || id < pageToken
+}
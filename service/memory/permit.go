@@ -0,0 +1,241 @@
+package memory
+
+import (
+	"fmt"
+	"time"
+
+	pb "github.com/meateam/permit-service/proto"
+	"github.com/meateam/permit-service/service"
+)
+
+// Permit is the struct that represents a permit as it's stored in memory.
+type Permit struct {
+	ID     string
+	FileID string
+	Status string
+	UserID string
+	ReqID  string
+
+	ExpiresAt time.Time
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DecidedAt time.Time
+	History   []service.StatusChange
+
+	// version is the version of the store the permit was last written at.
+	version uint64
+}
+
+// newPermit returns a Permit with the values of permit.
+func newPermit(permit service.Permit) *Permit {
+	return &Permit{
+		ID:        permit.GetID(),
+		FileID:    permit.GetFileID(),
+		Status:    permit.GetStatus(),
+		UserID:    permit.GetUserID(),
+		ReqID:     permit.GetReqID(),
+		ExpiresAt: permit.GetExpiresAt(),
+		CreatedAt: permit.GetCreatedAt(),
+		UpdatedAt: permit.GetUpdatedAt(),
+		DecidedAt: permit.GetDecidedAt(),
+		History:   append([]service.StatusChange(nil), permit.GetHistory()...),
+	}
+}
+
+// clone returns a copy of p that doesn't share its history with p.
+func (p *Permit) clone() *Permit {
+	permit := *p
+	permit.History = append([]service.StatusChange(nil), p.History...)
+
+	return &permit
+}
+
+// GetID returns p.ID.
+func (p Permit) GetID() string {
+	return p.ID
+}
+
+// SetID sets p.ID to id.
+func (p *Permit) SetID(id string) error {
+	if p == nil {
+		panic("p == nil")
+	}
+
+	p.ID = id
+	return nil
+}
+
+// GetFileID returns p.FileID.
+func (p Permit) GetFileID() string {
+	return p.FileID
+}
+
+// SetFileID sets p.FileID to fileID.
+func (p *Permit) SetFileID(fileID string) error {
+	if p == nil {
+		panic("p == nil")
+	}
+
+	if fileID == "" {
+		return fmt.Errorf("FileID is required")
+	}
+
+	p.FileID = fileID
+	return nil
+}
+
+// GetStatus returns p.Status.
+func (p Permit) GetStatus() string {
+	return p.Status
+}
+
+// SetStatus sets p.Status to status.
+func (p *Permit) SetStatus(status string) error {
+	if p == nil {
+		panic("p == nil")
+	}
+
+	p.Status = status
+	return nil
+}
+
+// GetExpiresAt returns p.ExpiresAt.
+func (p Permit) GetExpiresAt() time.Time {
+	return p.ExpiresAt
+}
+
+// SetExpiresAt sets p.ExpiresAt to expiresAt.
+func (p *Permit) SetExpiresAt(expiresAt time.Time) error {
+	if p == nil {
+		panic("p == nil")
+	}
+
+	p.ExpiresAt = expiresAt
+	return nil
+}
+
+// GetCreatedAt returns p.CreatedAt.
+func (p Permit) GetCreatedAt() time.Time {
+	return p.CreatedAt
+}
+
+// SetCreatedAt sets p.CreatedAt to createdAt.
+func (p *Permit) SetCreatedAt(createdAt time.Time) error {
+	if p == nil {
+		panic("p == nil")
+	}
+
+	p.CreatedAt = createdAt
+	return nil
+}
+
+// GetUpdatedAt returns p.UpdatedAt.
+func (p Permit) GetUpdatedAt() time.Time {
+	return p.UpdatedAt
+}
+
+// SetUpdatedAt sets p.UpdatedAt to updatedAt.
+func (p *Permit) SetUpdatedAt(updatedAt time.Time) error {
+	if p == nil {
+		panic("p == nil")
+	}
+
+	p.UpdatedAt = updatedAt
+	return nil
+}
+
+// GetDecidedAt returns p.DecidedAt.
+func (p Permit) GetDecidedAt() time.Time {
+	return p.DecidedAt
+}
+
+// SetDecidedAt sets p.DecidedAt to decidedAt.
+func (p *Permit) SetDecidedAt(decidedAt time.Time) error {
+	if p == nil {
+		panic("p == nil")
+	}
+
+	p.DecidedAt = decidedAt
+	return nil
+}
+
+// GetHistory returns the status history of p.
+func (p Permit) GetHistory() []service.StatusChange {
+	return append([]service.StatusChange(nil), p.History...)
+}
+
+// AppendHistory appends change to the status history of p.
+func (p *Permit) AppendHistory(change service.StatusChange) error {
+	if p == nil {
+		panic("p == nil")
+	}
+
+	if change.Status == "" {
+		return fmt.Errorf("status is required")
+	}
+
+	p.History = append(p.History, change)
+	return nil
+}
+
+// GetUserID returns p.UserID.
+func (p Permit) GetUserID() string {
+	return p.UserID
+}
+
+// SetUserID sets p.UserID to userID.
+func (p *Permit) SetUserID(userID string) error {
+	if p == nil {
+		panic("p == nil")
+	}
+
+	if userID == "" {
+		return fmt.Errorf("UserID is required")
+	}
+
+	p.UserID = userID
+	return nil
+}
+
+// GetReqID returns p.ReqID.
+func (p Permit) GetReqID() string {
+	return p.ReqID
+}
+
+// SetReqID sets p.ReqID to reqID.
+func (p *Permit) SetReqID(reqID string) error {
+	if p == nil {
+		panic("p == nil")
+	}
+
+	if reqID == "" {
+		return fmt.Errorf("reqID is required")
+	}
+
+	p.ReqID = reqID
+	return nil
+}
+
+// MarshalProto marshals p into a permission.
+func (p Permit) MarshalProto(permit *pb.PermitObject) error {
+	permit.ReqID = p.GetReqID()
+	permit.FileID = p.GetFileID()
+	permit.UserID = p.GetUserID()
+	permit.Status = p.GetStatus()
+	permit.CreatedAt = service.UnixMillis(p.GetCreatedAt())
+	permit.UpdatedAt = service.UnixMillis(p.GetUpdatedAt())
+	permit.DecidedAt = service.UnixMillis(p.GetDecidedAt())
+	permit.ExpiresAt = service.UnixMillis(p.GetExpiresAt())
+
+	permit.History = make([]*pb.StatusChange, 0, len(p.History))
+	for _, change := range p.History {
+		changeProto := &pb.StatusChange{}
+		if err := change.MarshalProto(changeProto); err != nil {
+			return err
+		}
+
+		permit.History = append(permit.History, changeProto)
+	}
+
+	return nil
+}
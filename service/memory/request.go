@@ -0,0 +1,155 @@
+package memory
+
+import (
+	"time"
+
+	pb "github.com/meateam/permit-service/proto"
+	"github.com/meateam/permit-service/service"
+)
+
+// Request is the struct that represents a permit request as it's stored in memory.
+type Request struct {
+	ID             string
+	ReqID          string
+	FileID         string
+	FileName       string
+	SharerID       string
+	Approvers      []string
+	Classification string
+	Info           string
+	Users          []service.UserType
+	CreatedAt      time.Time
+	ExpiresAt      time.Time
+	ApprovalID     string
+	IdempotencyKey string
+
+	Results           []service.UserResult
+	Submission        string
+	SubmissionMessage string
+}
+
+// newRequest returns the Request of the approval request created at createdAt.
+func newRequest(request service.ApprovalReqType, createdAt time.Time) *Request {
+	return &Request{
+		ReqID:          request.ID,
+		FileID:         request.FileID,
+		FileName:       request.FileName,
+		SharerID:       request.From,
+		Approvers:      append([]string(nil), request.Approvers...),
+		Classification: request.Classification,
+		Info:           request.Info,
+		Users:          append([]service.UserType(nil), request.To...),
+		CreatedAt:      createdAt,
+		ExpiresAt:      service.FromUnixMillis(request.ExpiresAt),
+		IdempotencyKey: request.IdempotencyKey,
+		Results:        append([]service.UserResult(nil), request.Results...),
+		Submission:     service.SubmissionQueued,
+	}
+}
+
+// clone returns a copy of r.
+func (r *Request) clone() *Request {
+	request := *r
+	return &request
+}
+
+// GetID returns r.ID.
+func (r Request) GetID() string {
+	return r.ID
+}
+
+// GetReqID returns r.ReqID.
+func (r Request) GetReqID() string {
+	return r.ReqID
+}
+
+// GetFileID returns r.FileID.
+func (r Request) GetFileID() string {
+	return r.FileID
+}
+
+// GetFileName returns r.FileName.
+func (r Request) GetFileName() string {
+	return r.FileName
+}
+
+// GetSharerID returns r.SharerID.
+func (r Request) GetSharerID() string {
+	return r.SharerID
+}
+
+// GetApprovers returns r.Approvers.
+func (r Request) GetApprovers() []string {
+	return r.Approvers
+}
+
+// GetClassification returns r.Classification.
+func (r Request) GetClassification() string {
+	return r.Classification
+}
+
+// GetInfo returns r.Info.
+func (r Request) GetInfo() string {
+	return r.Info
+}
+
+// GetUsers returns r.Users.
+func (r Request) GetUsers() []service.UserType {
+	return r.Users
+}
+
+// GetCreatedAt returns r.CreatedAt.
+func (r Request) GetCreatedAt() time.Time {
+	return r.CreatedAt
+}
+
+// GetExpiresAt returns r.ExpiresAt.
+func (r Request) GetExpiresAt() time.Time {
+	return r.ExpiresAt
+}
+
+// GetApprovalID returns r.ApprovalID.
+func (r Request) GetApprovalID() string {
+	return r.ApprovalID
+}
+
+// GetIdempotencyKey returns r.IdempotencyKey.
+func (r Request) GetIdempotencyKey() string {
+	return r.IdempotencyKey
+}
+
+// GetResults returns r.Results.
+func (r Request) GetResults() []service.UserResult {
+	return r.Results
+}
+
+// GetSubmission returns r.Submission.
+func (r Request) GetSubmission() string {
+	return r.Submission
+}
+
+// GetSubmissionMessage returns r.SubmissionMessage.
+func (r Request) GetSubmissionMessage() string {
+	return r.SubmissionMessage
+}
+
+// MarshalProto marshals r into a permit request.
+func (r Request) MarshalProto(request *pb.PermitRequestObject) error {
+	request.ReqID = r.GetReqID()
+	request.FileID = r.GetFileID()
+	request.FileName = r.GetFileName()
+	request.SharerID = r.GetSharerID()
+	request.Approvers = r.GetApprovers()
+	request.Classification = r.GetClassification()
+	request.Info = r.GetInfo()
+	request.CreatedAt = service.UnixMillis(r.GetCreatedAt())
+	request.ExpiresAt = service.UnixMillis(r.GetExpiresAt())
+	request.ApprovalID = r.GetApprovalID()
+
+	request.Users = make([]*pb.User, 0, len(r.Users))
+	for _, user := range r.Users {
+		request.Users = append(request.Users, &pb.User{Id: user.ID, FullName: user.Name})
+	}
+
+	return nil
+}
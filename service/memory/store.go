@@ -0,0 +1,704 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/meateam/permit-service/service"
+)
+
+var (
+	// ErrNotFound is returned from the Store when no object matches a filter.
+	ErrNotFound = errors.New("not found")
+
+	// ErrDuplicate is returned from the Store when creating a permit request with the reqID of an
+	// existing request, or of the same sharer with the same idempotency key as an existing request.
+	ErrDuplicate = errors.New("duplicate key")
+)
+
+// Filter is the filter of the permits of a Store, which matches a permit if it returns true.
+type Filter func(permit service.Permit) bool
+
+// RequestFilter is the filter of the permit requests of a Store, which matches a request if it returns true.
+type RequestFilter func(request service.PermitRequest) bool
+
+// OutboxFilter is the filter of the outbox messages of a Store, which matches a message if it returns true.
+type OutboxFilter func(message service.OutboxMessage) bool
+
+// transactionKey is the key of the Store whose transaction a context runs in.
+type transactionKey struct{}
+
+// Store holds the permits, the permit requests and the outbox in memory and implements
+// the service.Store interface. It's safe for concurrent use.
+type Store struct {
+	mu       sync.RWMutex
+	permits  map[string]*Permit
+	requests map[string]*Request
+	outbox   map[string]*service.OutboxMessage
+
+	// lastID is the last ID given to a stored object, IDs increase with the time they were given.
+	lastID uint64
+
+	// version is increased on every write of a permit, and changed is closed and replaced
+	// to notify the watchers of the permits of the write.
+	version uint64
+	changed chan struct{}
+
+	// undo holds the functions that revert the writes of the running transaction,
+	// it's nil when no transaction is running.
+	undo []func()
+}
+
+var _ service.Store = &Store{}
+
+// NewStore returns an empty Store.
+func NewStore() *Store {
+	return &Store{
+		permits:  map[string]*Permit{},
+		requests: map[string]*Request{},
+		outbox:   map[string]*service.OutboxMessage{},
+		changed:  make(chan struct{}),
+	}
+}
+
+// HealthCheck returns true, the store is always healthy.
+func (s *Store) HealthCheck(ctx context.Context) (bool, error) {
+	return true, nil
+}
+
+// Create creates a permit of a file to a user,
+// If a permit of the same request and user already exists then its updated to have the permit values,
+// If successful returns the permit and a nil error,
+// otherwise returns nil and non-nil error if any occurred.
+func (s *Store) Create(ctx context.Context, permit service.Permit) (service.Permit, error) {
+	if err := validatePermit(permit); err != nil {
+		return nil, err
+	}
+
+	defer s.lock(ctx)()
+
+	created := s.upsert(permit)
+	s.written(ctx)
+
+	return created.clone(), nil
+}
+
+// CreateMany creates all of permits at once,
+// a permit of the same request and user that already exists is updated to have the permit values.
+// If any of permits is invalid then none of them are written.
+func (s *Store) CreateMany(ctx context.Context, permits []service.Permit) error {
+	if len(permits) == 0 {
+		return fmt.Errorf("at least one permit is required")
+	}
+
+	for _, permit := range permits {
+		if err := validatePermit(permit); err != nil {
+			return err
+		}
+	}
+
+	defer s.lock(ctx)()
+
+	for _, permit := range permits {
+		s.upsert(permit)
+	}
+
+	s.written(ctx)
+
+	return nil
+}
+
+// Get finds the first permit that matches filter, which must be a Filter or nil to match any permit.
+// If successful returns the permit, and a nil error, if the permit is not found it would return
+// nil and ErrNotFound, otherwise returns nil and non-nil error if any occurred.
+func (s *Store) Get(ctx context.Context, filter interface{}) (service.Permit, error) {
+	permits, err := s.GetAll(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(permits) == 0 {
+		return nil, ErrNotFound
+	}
+
+	return permits[0], nil
+}
+
+// GetAll finds all permits that match filter, which must be a Filter or nil to match any permit,
+// from the oldest permit to the newest.
+// If successful returns the permits, and a nil error,
+// otherwise returns nil and non-nil error if any occurred.
+func (s *Store) GetAll(ctx context.Context, filter interface{}) ([]service.Permit, error) {
+	match, err := toFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	defer s.rlock(ctx)()
+
+	permits := []service.Permit{}
+	for _, permit := range s.find(match) {
+		permits = append(permits, permit.clone())
+	}
+
+	return permits, nil
+}
+
+// GetPage finds up to limit permits that match filter, from the newest permit to the oldest.
+// If successful returns the permits, and a nil error,
+// otherwise returns nil and non-nil error if any occurred.
+func (s *Store) GetPage(ctx context.Context, filter Filter, limit int64) ([]service.Permit, error) {
+	defer s.rlock(ctx)()
+
+	matched := s.find(filter)
+
+	permits := []service.Permit{}
+	for i := len(matched) - 1; i >= 0 && int64(len(permits)) < limit; i-- {
+		permits = append(permits, matched[i].clone())
+	}
+
+	return permits, nil
+}
+
+// Watch calls send with each permit that matches filter whenever it's created or updated,
+// until ctx is done or send returns an error. A permit written several times before it's sent is sent
+// once with its latest values.
+func (s *Store) Watch(ctx context.Context, filter Filter, send func(service.Permit) error) error {
+	s.mu.RLock()
+	version, changed := s.version, s.changed
+	s.mu.RUnlock()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-changed:
+		}
+
+		s.mu.RLock()
+		permits := []*Permit{}
+		for _, permit := range s.permits {
+			if permit.version > version && (filter == nil || filter(permit)) {
+				permits = append(permits, permit.clone())
+			}
+		}
+
+		version, changed = s.version, s.changed
+		s.mu.RUnlock()
+
+		sort.Slice(permits, func(i, j int) bool {
+			return permits[i].version < permits[j].version
+		})
+
+		for _, permit := range permits {
+			if err := send(permit); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// UpdateStatus updates the permits that match filter, which must be a Filter or nil to match
+// any permit, by change. Returns the number of updated permits.
+func (s *Store) UpdateStatus(ctx context.Context, filter interface{}, change service.StatusChange) (int64, error) {
+	if change.Status == "" {
+		return 0, fmt.Errorf("status is required")
+	}
+
+	match, err := toFilter(filter)
+	if err != nil {
+		return 0, err
+	}
+
+	defer s.lock(ctx)()
+
+	var updated int64
+	for _, permit := range s.find(match) {
+		permit = permit.clone()
+		permit.Status = change.Status
+		permit.UpdatedAt = change.Time
+		if service.IsDecision(change.Status) {
+			permit.DecidedAt = change.Time
+		}
+
+		permit.History = append(permit.History, change)
+		s.touch(permit)
+		s.setPermit(permit)
+		updated++
+	}
+
+	s.written(ctx)
+
+	return updated, nil
+}
+
+// CreateRequest stores the permit request, returns ErrDuplicate if a request with the same reqID,
+// or of the same sharer with the same idempotency key, already exists.
+func (s *Store) CreateRequest(ctx context.Context, request *Request) error {
+	if request.ReqID == "" {
+		return fmt.Errorf("reqID is required")
+	}
+
+	defer s.lock(ctx)()
+
+	for _, existing := range s.requests {
+		if existing.ReqID == request.ReqID {
+			return ErrDuplicate
+		}
+
+		if request.IdempotencyKey != "" &&
+			existing.SharerID == request.SharerID &&
+			existing.IdempotencyKey == request.IdempotencyKey {
+			return ErrDuplicate
+		}
+	}
+
+	stored := request.clone()
+	stored.ID = s.nextID()
+	s.setRequest(stored)
+	s.written(ctx)
+
+	return nil
+}
+
+// SetRequestSubmission sets the submission of the approval request of the permit request reqID,
+// along with the approvalID the approval service gave it or the message it was rejected with, if not empty.
+// Returns ErrNotFound if there is no such request.
+func (s *Store) SetRequestSubmission(
+	ctx context.Context,
+	reqID string,
+	submission string,
+	approvalID string,
+	message string,
+) error {
+	defer s.lock(ctx)()
+
+	existing, ok := s.requests[reqID]
+	if !ok {
+		return ErrNotFound
+	}
+
+	request := existing.clone()
+	request.Submission = submission
+	if approvalID != "" {
+		request.ApprovalID = approvalID
+	}
+
+	if message != "" {
+		request.SubmissionMessage = message
+	}
+
+	s.setRequest(request)
+	s.written(ctx)
+
+	return nil
+}
+
+// GetRequest finds the oldest permit request that matches filter,
+// if successful returns the request, and a nil error,
+// if the request is not found it would return nil and ErrNotFound.
+func (s *Store) GetRequest(ctx context.Context, filter RequestFilter) (service.PermitRequest, error) {
+	defer s.rlock(ctx)()
+
+	requests := s.findRequests(filter)
+	if len(requests) == 0 {
+		return nil, ErrNotFound
+	}
+
+	return requests[0].clone(), nil
+}
+
+// GetRequestsPage finds up to limit permit requests that match filter, from the newest request
+// to the oldest, each joined with its permits. If permitStatuses is not empty, only requests with
+// at least one permit whose status is one of permitStatuses are found.
+func (s *Store) GetRequestsPage(
+	ctx context.Context,
+	filter RequestFilter,
+	permitStatuses []string,
+	limit int64,
+) ([]service.RequestPermits, error) {
+	defer s.rlock(ctx)()
+
+	matched := s.findRequests(filter)
+
+	requests := []service.RequestPermits{}
+	for i := len(matched) - 1; i >= 0 && int64(len(requests)) < limit; i-- {
+		reqID := matched[i].ReqID
+		permits := s.find(func(permit service.Permit) bool {
+			return permit.GetReqID() == reqID
+		})
+
+		if len(permitStatuses) > 0 && !anyHasStatus(permits, permitStatuses) {
+			continue
+		}
+
+		requestPermits := service.RequestPermits{Request: matched[i].clone(), Permits: []service.Permit{}}
+		for _, permit := range permits {
+			requestPermits.Permits = append(requestPermits.Permits, permit.clone())
+		}
+
+		requests = append(requests, requestPermits)
+	}
+
+	return requests, nil
+}
+
+// WithTransaction runs fn in a transaction, fn must use the context it is called with for all of
+// the operations of s that should be part of the transaction, and must not use it after it returns.
+// If fn returns a non-nil error then all of its writes are reverted and the error is returned.
+// The transaction holds s for writing, so other operations of s wait until it's over.
+func (s *Store) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if s.inTransaction(ctx) {
+		return fn(ctx)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.undo = []func(){}
+	defer func() {
+		s.undo = nil
+	}()
+
+	if err := fn(context.WithValue(ctx, transactionKey{}, s)); err != nil {
+		for i := len(s.undo) - 1; i >= 0; i-- {
+			s.undo[i]()
+		}
+
+		return err
+	}
+
+	s.notify()
+
+	return nil
+}
+
+// CreateOutboxMessage stores message in the outbox, returns the ID of the stored message.
+func (s *Store) CreateOutboxMessage(ctx context.Context, message service.OutboxMessage) (string, error) {
+	defer s.lock(ctx)()
+
+	message.ID = s.nextID()
+	s.setOutboxMessage(message.ID, &message)
+	s.written(ctx)
+
+	return message.ID, nil
+}
+
+// ClaimOutboxMessage finds the outbox message that is due the earliest at now, and
+// postpones its next attempt by lease so no one else would claim it while it's delivered,
+// if the outbox has no due messages it would return ErrNotFound,
+// otherwise returns the claimed message with its attempts incremented.
+func (s *Store) ClaimOutboxMessage(ctx context.Context, now time.Time, lease time.Duration) (service.OutboxMessage, error) {
+	defer s.lock(ctx)()
+
+	var due *service.OutboxMessage
+	for _, message := range s.findOutboxMessages(nil) {
+		if message.NextAttemptAt.After(now) {
+			continue
+		}
+
+		if due == nil || message.NextAttemptAt.Before(due.NextAttemptAt) {
+			due = message
+		}
+	}
+
+	if due == nil {
+		return service.OutboxMessage{}, ErrNotFound
+	}
+
+	claimed := *due
+	claimed.NextAttemptAt = now.Add(lease)
+	claimed.Attempts++
+	s.setOutboxMessage(claimed.ID, &claimed)
+	s.written(ctx)
+
+	return claimed, nil
+}
+
+// RetryOutboxMessage schedules the next attempt of the outbox message id to nextAttemptAt
+// and records lastError as the reason its previous attempt failed.
+func (s *Store) RetryOutboxMessage(ctx context.Context, id string, nextAttemptAt time.Time, lastError string) error {
+	defer s.lock(ctx)()
+
+	existing, ok := s.outbox[id]
+	if !ok {
+		return nil
+	}
+
+	message := *existing
+	message.NextAttemptAt = nextAttemptAt
+	message.LastError = lastError
+	s.setOutboxMessage(id, &message)
+	s.written(ctx)
+
+	return nil
+}
+
+// GetOutboxMessages finds all of the outbox messages that match filter, from the oldest to the newest.
+func (s *Store) GetOutboxMessages(ctx context.Context, filter OutboxFilter) ([]service.OutboxMessage, error) {
+	defer s.rlock(ctx)()
+
+	messages := []service.OutboxMessage{}
+	for _, message := range s.findOutboxMessages(filter) {
+		messages = append(messages, *message)
+	}
+
+	return messages, nil
+}
+
+// DeleteOutboxMessages removes all of the outbox messages that match filter,
+// returns the number of removed messages.
+func (s *Store) DeleteOutboxMessages(ctx context.Context, filter OutboxFilter) (int64, error) {
+	defer s.lock(ctx)()
+
+	var deleted int64
+	for _, message := range s.findOutboxMessages(filter) {
+		s.setOutboxMessage(message.ID, nil)
+		deleted++
+	}
+
+	s.written(ctx)
+
+	return deleted, nil
+}
+
+// DeleteOutboxMessage removes the outbox message id from the outbox.
+func (s *Store) DeleteOutboxMessage(ctx context.Context, id string) error {
+	defer s.lock(ctx)()
+
+	if _, ok := s.outbox[id]; ok {
+		s.setOutboxMessage(id, nil)
+		s.written(ctx)
+	}
+
+	return nil
+}
+
+// lock locks s for writing, unless ctx runs in a transaction of s which already holds it.
+// Returns the function that unlocks it.
+func (s *Store) lock(ctx context.Context) func() {
+	if s.inTransaction(ctx) {
+		return func() {}
+	}
+
+	s.mu.Lock()
+	return s.mu.Unlock
+}
+
+// rlock locks s for reading, unless ctx runs in a transaction of s which already holds it.
+// Returns the function that unlocks it.
+func (s *Store) rlock(ctx context.Context) func() {
+	if s.inTransaction(ctx) {
+		return func() {}
+	}
+
+	s.mu.RLock()
+	return s.mu.RUnlock
+}
+
+// inTransaction returns true if ctx runs in a transaction of s.
+func (s *Store) inTransaction(ctx context.Context) bool {
+	store, _ := ctx.Value(transactionKey{}).(*Store)
+	return store == s
+}
+
+// written notifies the watchers of the permits of a write made with ctx,
+// a write in a transaction is notified when the transaction is over. s.mu must be held for writing.
+func (s *Store) written(ctx context.Context) {
+	if !s.inTransaction(ctx) {
+		s.notify()
+	}
+}
+
+// upsert creates permit, or updates the permit of the same request and user to have the
+// permit values, keeping its history. s.mu must be held for writing.
+func (s *Store) upsert(permit service.Permit) *Permit {
+	stored := newPermit(permit)
+	stored.ID = ""
+
+	// An existing permit of the request keeps its history, and is undecided again until it's decided.
+	stored.DecidedAt = time.Time{}
+	for _, existing := range s.permits {
+		if existing.ReqID == stored.ReqID && existing.UserID == stored.UserID {
+			stored.ID = existing.ID
+			stored.History = append(existing.clone().History, stored.History...)
+			break
+		}
+	}
+
+	if stored.ID == "" {
+		stored.ID = s.nextID()
+	}
+
+	s.touch(stored)
+	s.setPermit(stored)
+
+	return stored
+}
+
+// setPermit stores permit, replacing the stored permit with its ID. s.mu must be held for writing.
+func (s *Store) setPermit(permit *Permit) {
+	id := permit.ID
+	previous, ok := s.permits[id]
+	s.permits[id] = permit
+
+	s.onRollback(func() {
+		if ok {
+			s.permits[id] = previous
+		} else {
+			delete(s.permits, id)
+		}
+	})
+}
+
+// setRequest stores request, replacing the stored request with its reqID. s.mu must be held for writing.
+func (s *Store) setRequest(request *Request) {
+	reqID := request.ReqID
+	previous, ok := s.requests[reqID]
+	s.requests[reqID] = request
+
+	s.onRollback(func() {
+		if ok {
+			s.requests[reqID] = previous
+		} else {
+			delete(s.requests, reqID)
+		}
+	})
+}
+
+// setOutboxMessage stores message in the outbox as the message id, or removes the message id
+// if message is nil. s.mu must be held for writing.
+func (s *Store) setOutboxMessage(id string, message *service.OutboxMessage) {
+	previous, ok := s.outbox[id]
+	if message == nil {
+		delete(s.outbox, id)
+	} else {
+		s.outbox[id] = message
+	}
+
+	s.onRollback(func() {
+		if ok {
+			s.outbox[id] = previous
+		} else {
+			delete(s.outbox, id)
+		}
+	})
+}
+
+// onRollback records undo as reverting a write of the running transaction, if any.
+// s.mu must be held for writing.
+func (s *Store) onRollback(undo func()) {
+	if s.undo != nil {
+		s.undo = append(s.undo, undo)
+	}
+}
+
+// find returns the stored permits that match, or all of them if match is nil,
+// from the oldest to the newest. s.mu must be held.
+func (s *Store) find(match Filter) []*Permit {
+	permits := []*Permit{}
+	for _, permit := range s.permits {
+		if match == nil || match(permit) {
+			permits = append(permits, permit)
+		}
+	}
+
+	sort.Slice(permits, func(i, j int) bool {
+		return permits[i].ID < permits[j].ID
+	})
+
+	return permits
+}
+
+// findRequests returns the stored requests that match, or all of them if match is nil,
+// from the oldest to the newest. s.mu must be held.
+func (s *Store) findRequests(match RequestFilter) []*Request {
+	requests := []*Request{}
+	for _, request := range s.requests {
+		if match == nil || match(request) {
+			requests = append(requests, request)
+		}
+	}
+
+	sort.Slice(requests, func(i, j int) bool {
+		return requests[i].ID < requests[j].ID
+	})
+
+	return requests
+}
+
+// findOutboxMessages returns the outbox messages that match, or all of them if match is nil,
+// from the oldest to the newest. s.mu must be held.
+func (s *Store) findOutboxMessages(match OutboxFilter) []*service.OutboxMessage {
+	messages := []*service.OutboxMessage{}
+	for _, message := range s.outbox {
+		if match == nil || match(*message) {
+			messages = append(messages, message)
+		}
+	}
+
+	sort.Slice(messages, func(i, j int) bool {
+		return messages[i].ID < messages[j].ID
+	})
+
+	return messages
+}
+
+// nextID returns a new ID, which is greater than every ID given before it. s.mu must be held for writing.
+func (s *Store) nextID() string {
+	s.lastID++
+	return formatID(s.lastID)
+}
+
+// touch marks permit as written at a new version of the store. s.mu must be held for writing.
+func (s *Store) touch(permit *Permit) {
+	s.version++
+	permit.version = s.version
+}
+
+// notify notifies the watchers of the permits of the permits written since they were notified.
+// s.mu must be held for writing.
+func (s *Store) notify() {
+	close(s.changed)
+	s.changed = make(chan struct{})
+}
+
+// validatePermit returns a non-nil error if permit is missing a required field.
+func validatePermit(permit service.Permit) error {
+	if permit.GetFileID() == "" {
+		return fmt.Errorf("fileID is required")
+	}
+
+	if permit.GetUserID() == "" {
+		return fmt.Errorf("userID is required")
+	}
+
+	if permit.GetReqID() == "" {
+		return fmt.Errorf("reqID is required")
+	}
+
+	return nil
+}
+
+// toFilter returns the Filter of filter, which is either a Filter or nil to match any permit.
+func toFilter(filter interface{}) (Filter, error) {
+	switch f := filter.(type) {
+	case nil:
+		return nil, nil
+	case Filter:
+		return f, nil
+	case func(permit service.Permit) bool:
+		return f, nil
+	default:
+		return nil, fmt.Errorf("unsupported filter type %T", filter)
+	}
+}
+
+// formatID returns the string of the numeric id, ids of the same length sort in the order of their numbers.
+func formatID(id uint64) string {
+	return fmt.Sprintf("%024x", id)
+}
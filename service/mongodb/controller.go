@@ -2,7 +2,7 @@ package mongodb
 
 import (
 	"context"
-	"fmt"
+	"time"
 
 	pb "github.com/meateam/permit-service/proto"
 	"github.com/meateam/permit-service/service"
@@ -12,6 +12,9 @@ import (
 	"google.golang.org/grpc/status"
 )
 
+// duplicateKeyCode is the error code of writing a document that violates a unique index.
+const duplicateKeyCode = 11000
+
 // Controller is the permisison service business logic implementation using MongoStore.
 type Controller struct {
 	store MongoStore
@@ -33,17 +36,59 @@ func (c Controller) HealthCheck(ctx context.Context) (bool, error) {
 	return c.store.HealthCheck(ctx)
 }
 
-// CreatePermit creates a permit in store and returns its unique ID.
-func (c Controller) CreatePermit(ctx context.Context, reqID string, fileID string, userID string, status string) (service.Permit, error) {
-	permit := &BSON{FileID: fileID, ReqID: reqID, UserID: userID, Status: status}
-	createdPermission, err := c.store.Create(ctx, permit)
+// CreatePermits stores request and creates the permits of its file to each of its users with the
+// initial status change, and stores message in the outbox, all in a single transaction, so either
+// all of them are stored or none of them are. Returns the created permits, or a status error if
+// the transaction failed.
+func (c Controller) CreatePermits(
+	ctx context.Context,
+	request service.ApprovalReqType,
+	change service.StatusChange,
+	message service.OutboxMessage,
+) ([]service.Permit, error) {
+	permits := make([]service.Permit, 0, len(request.To))
+	for _, user := range request.To {
+		permit := &BSON{
+			FileID:    request.FileID,
+			ReqID:     request.ID,
+			UserID:    user.ID,
+			Status:    change.Status,
+			CreatedAt: change.Time,
+			UpdatedAt: change.Time,
+			ExpiresAt: service.FromUnixMillis(request.ExpiresAt),
+		}
+
+		if err := permit.AppendHistory(change); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+
+		permits = append(permits, permit)
+	}
+
+	requestBSON := newRequestBSON(request, message.CreatedAt)
+
+	err := c.store.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := c.store.CreateRequest(ctx, requestBSON); err != nil {
+			return err
+		}
+
+		if err := c.store.CreateMany(ctx, permits); err != nil {
+			return err
+		}
+
+		_, err := c.store.CreateOutboxMessage(ctx, message)
+		return err
+	})
+
 	if err != nil {
-		return nil, fmt.Errorf("failed creating permit %v", err)
+		return nil, toStatusError(err, "failed creating permits")
 	}
-	return createdPermission, nil
+
+	return permits, nil
 }
 
-// GetPermitsByFileID returns the statuses of the permits of each user associated with the fileID.
+// GetPermitsByFileID returns the status of the effective permit of each user associated with the fileID,
+// which is empty if the file has no permits.
 func (c Controller) GetPermitsByFileID(ctx context.Context, fileID string) ([]*pb.UserStatus, error) {
 	filter := bson.D{
 		bson.E{
@@ -52,28 +97,342 @@ func (c Controller) GetPermitsByFileID(ctx context.Context, fileID string) ([]*p
 		},
 	}
 
+	permits, err := c.store.GetAll(ctx, filter)
+	if err != nil {
+		return nil, toStatusError(err, "failed retrieving permits")
+	}
+
+	return service.EffectiveUserStatuses(permits, time.Now()), nil
+}
+
+// GetPermit returns the effective permit of userID to fileID out of the permits of all of their requests,
+// if no such permit exists it returns a codes.NotFound status error.
+func (c Controller) GetPermit(ctx context.Context, fileID string, userID string) (service.Permit, error) {
+	filter := bson.D{
+		bson.E{
+			Key:   PermitBSONFileIDField,
+			Value: fileID,
+		},
+		bson.E{
+			Key:   PermitBSONUserIDField,
+			Value: userID,
+		},
+	}
+
 	permits, err := c.store.GetAll(ctx, filter)
 	if err != nil && err != mongo.ErrNoDocuments {
-		return nil, err
+		return nil, toStatusError(err, "failed retrieving permit")
+	}
+
+	if err == mongo.ErrNoDocuments || len(permits) == 0 {
+		return nil, status.Errorf(codes.NotFound, "permit of user %s to file %s not found", userID, fileID)
+	}
+
+	return service.EffectivePermit(permits, time.Now()), nil
+}
+
+// GetPermitsByKeys returns the effective permits of the pairs of keys with a single query,
+// mapped by their keys. Keys without a permit are missing from the returned map.
+func (c Controller) GetPermitsByKeys(ctx context.Context, keys []service.PermitKey) (map[service.PermitKey]service.Permit, error) {
+	pairs := bson.A{}
+	seen := make(map[service.PermitKey]bool, len(keys))
+	for _, key := range keys {
+		if seen[key] {
+			continue
+		}
+
+		seen[key] = true
+		pairs = append(pairs, bson.D{
+			bson.E{
+				Key:   PermitBSONFileIDField,
+				Value: key.FileID,
+			},
+			bson.E{
+				Key:   PermitBSONUserIDField,
+				Value: key.UserID,
+			},
+		})
+	}
+
+	if len(pairs) == 0 {
+		return map[service.PermitKey]service.Permit{}, nil
+	}
+
+	permits, err := c.store.GetAll(ctx, bson.D{bson.E{Key: "$or", Value: pairs}})
+	if err != nil {
+		return nil, toStatusError(err, "failed retrieving permits")
+	}
+
+	return service.EffectivePermitsByKey(permits, time.Now()), nil
+}
+
+// ListPermitsByUser returns a page of up to pageSize permits of userID whose status is one of statuses,
+// or of any status if statuses is empty, starting after pageToken.
+// Returns the permits and the token of the next page, which is empty if there are no more pages.
+func (c Controller) ListPermitsByUser(
+	ctx context.Context,
+	userID string,
+	statuses []string,
+	pageSize int64,
+	pageToken string,
+) ([]service.Permit, string, error) {
+	filter := bson.D{
+		bson.E{
+			Key:   PermitBSONUserIDField,
+			Value: userID,
+		},
+	}
+
+	if len(statuses) > 0 {
+		filter = withStatuses(filter, statuses)
+	}
+
+	filter, err := withPageToken(filter, pageToken)
+	if err != nil {
+		return nil, "", err
 	}
 
+	// One extra permit is fetched to tell whether there is a next page.
+	permits, err := c.store.GetPage(ctx, filter, pageSize+1)
+	if err != nil {
+		return nil, "", toStatusError(err, "failed listing permits")
+	}
+
+	hasMore := int64(len(permits)) > pageSize
+	if hasMore {
+		permits = permits[:pageSize]
+	}
+
+	lastID := ""
+	if len(permits) > 0 {
+		lastID = permits[len(permits)-1].GetID()
+	}
+
+	return permits, service.NextPageToken(lastID, hasMore), nil
+}
+
+// ListPermitRequestsBySharer returns a page of up to pageSize permit requests of sharerID along with
+// their permits, starting after pageToken. If statuses is not empty, only requests with a permit
+// whose status is one of statuses are listed.
+// Returns the requests and the token of the next page, which is empty if there are no more pages.
+func (c Controller) ListPermitRequestsBySharer(
+	ctx context.Context,
+	sharerID string,
+	statuses []string,
+	pageSize int64,
+	pageToken string,
+) ([]service.RequestPermits, string, error) {
+	filter := bson.D{
+		bson.E{
+			Key:   RequestBSONSharerIDField,
+			Value: sharerID,
+		},
+	}
+
+	return c.listRequests(ctx, filter, statuses, pageSize, pageToken)
+}
+
+// ListPendingApprovals returns a page of up to pageSize permit requests that approverID is an approver of
+// and that have pending permits, along with their permits, starting after pageToken.
+// Returns the requests and the token of the next page, which is empty if there are no more pages.
+func (c Controller) ListPendingApprovals(
+	ctx context.Context,
+	approverID string,
+	pageSize int64,
+	pageToken string,
+) ([]service.RequestPermits, string, error) {
+	filter := bson.D{
+		bson.E{
+			Key:   RequestBSONApproversField,
+			Value: approverID,
+		},
+	}
+
+	return c.listRequests(ctx, filter, []string{service.StatusPending}, pageSize, pageToken)
+}
+
+// listRequests returns a page of up to pageSize permit requests that match filter, along with their
+// permits, starting after pageToken. If statuses is not empty, only requests with a permit whose
+// status is one of statuses are listed.
+func (c Controller) listRequests(
+	ctx context.Context,
+	filter bson.D,
+	statuses []string,
+	pageSize int64,
+	pageToken string,
+) ([]service.RequestPermits, string, error) {
+	filter, err := withPageToken(filter, pageToken)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// One extra request is fetched to tell whether there is a next page.
+	requests, err := c.store.GetRequestsPage(ctx, filter, statuses, pageSize+1)
+	if err != nil {
+		return nil, "", toStatusError(err, "failed listing requests")
+	}
+
+	hasMore := int64(len(requests)) > pageSize
+	if hasMore {
+		requests = requests[:pageSize]
+	}
+
+	lastID := ""
+	if len(requests) > 0 {
+		lastID = requests[len(requests)-1].Request.GetID()
+	}
+
+	return requests, service.NextPageToken(lastID, hasMore), nil
+}
+
+// GetPermitRequest returns the permit request reqID,
+// if no such request exists it returns a codes.NotFound status error.
+func (c Controller) GetPermitRequest(ctx context.Context, reqID string) (service.PermitRequest, error) {
+	filter := bson.D{
+		bson.E{
+			Key:   RequestBSONReqIDField,
+			Value: reqID,
+		},
+	}
+
+	request, err := c.store.GetRequest(ctx, filter)
 	if err == mongo.ErrNoDocuments {
-		return nil, status.Error(codes.NotFound, "permit not found")
+		return nil, status.Errorf(codes.NotFound, "request %s not found", reqID)
 	}
 
-	userStatuses := make([]*pb.UserStatus, 0, len(permits))
+	if err != nil {
+		return nil, toStatusError(err, "failed retrieving request")
+	}
+
+	return request, nil
+}
 
-	for _, permit := range permits {
-		userStatus := &pb.UserStatus{UserId: permit.GetUserID(), Status: permit.GetStatus()}
-		userStatuses = append(userStatuses, userStatus)
+// GetPermitRequestByIdempotencyKey returns the permit request of sharerID created with idempotencyKey,
+// if no such request exists it returns a codes.NotFound status error.
+func (c Controller) GetPermitRequestByIdempotencyKey(
+	ctx context.Context,
+	sharerID string,
+	idempotencyKey string,
+) (service.PermitRequest, error) {
+	filter := bson.D{
+		bson.E{
+			Key:   RequestBSONSharerIDField,
+			Value: sharerID,
+		},
+		bson.E{
+			Key:   RequestBSONIdempotencyKeyField,
+			Value: idempotencyKey,
+		},
+	}
+
+	request, err := c.store.GetRequest(ctx, filter)
+	if err == mongo.ErrNoDocuments {
+		return nil, status.Errorf(codes.NotFound, "no request of %s has idempotency key %s", sharerID, idempotencyKey)
 	}
 
-	return userStatuses, nil
+	if err != nil {
+		return nil, toStatusError(err, "failed retrieving request")
+	}
+
+	return request, nil
 }
 
-// HasPermit returns true if a permit exists with the given fileID and userID, and false if it does not.
-func (c Controller) HasPermit(ctx context.Context, fileID string, userID string) (bool, error) {
+// GetPermitsByReqID returns the permits created by the request reqID,
+// if the request has no permits it returns a codes.NotFound status error.
+func (c Controller) GetPermitsByReqID(ctx context.Context, reqID string) ([]service.Permit, error) {
 	filter := bson.D{
+		bson.E{
+			Key:   PermitBSONReqIDField,
+			Value: reqID,
+		},
+	}
+
+	permits, err := c.store.GetAll(ctx, filter)
+	if err != nil {
+		return nil, toStatusError(err, "failed retrieving permits")
+	}
+
+	if len(permits) == 0 {
+		return nil, status.Errorf(codes.NotFound, "request %s not found", reqID)
+	}
+
+	return permits, nil
+}
+
+// UpdatePermitStatus updates the permits of the request reqID whose status is one of
+// fromStatuses by change, returns true if any permit was updated, and false otherwise.
+func (c Controller) UpdatePermitStatus(
+	ctx context.Context,
+	reqID string,
+	fromStatuses []string,
+	change service.StatusChange,
+) (bool, error) {
+	filter := bson.D{
+		bson.E{
+			Key:   PermitBSONReqIDField,
+			Value: reqID,
+		},
+	}
+
+	updated, err := c.store.UpdateStatus(ctx, withStatuses(filter, fromStatuses), change)
+	if err != nil {
+		return false, toStatusError(err, "failed updating permit status")
+	}
+
+	return updated > 0, nil
+}
+
+// UpdateUserPermitStatuses applies each of updates to the permit of its user in the request reqID,
+// all in a single transaction. Returns the number of updated permits.
+func (c Controller) UpdateUserPermitStatuses(
+	ctx context.Context,
+	reqID string,
+	updates []service.UserStatusUpdate,
+) (int64, error) {
+	var updated int64
+	err := c.store.WithTransaction(ctx, func(ctx context.Context) error {
+		updated = 0
+		for _, update := range updates {
+			filter := bson.D{
+				bson.E{
+					Key:   PermitBSONReqIDField,
+					Value: reqID,
+				},
+				bson.E{
+					Key:   PermitBSONUserIDField,
+					Value: update.UserID,
+				},
+			}
+
+			count, err := c.store.UpdateStatus(ctx, withStatuses(filter, update.FromStatuses), update.Change)
+			if err != nil {
+				return err
+			}
+
+			updated += count
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return 0, toStatusError(err, "failed updating permit statuses")
+	}
+
+	return updated, nil
+}
+
+// RevokePermit updates the permits of userID to fileID, by all of their requests, whose status is one
+// of fromStatuses by change in a single transaction, and returns the permits that were revoked as they
+// were before change.
+func (c Controller) RevokePermit(
+	ctx context.Context,
+	fileID string,
+	userID string,
+	fromStatuses []string,
+	change service.StatusChange,
+) ([]service.Permit, error) {
+	filter := withStatuses(bson.D{
 		bson.E{
 			Key:   PermitBSONFileIDField,
 			Value: fileID,
@@ -82,27 +441,263 @@ func (c Controller) HasPermit(ctx context.Context, fileID string, userID string)
 			Key:   PermitBSONUserIDField,
 			Value: userID,
 		},
+	}, fromStatuses)
+
+	var revoked []service.Permit
+	err := c.store.WithTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		revoked, err = c.store.GetAll(ctx, filter)
+		if err != nil || len(revoked) == 0 {
+			return err
+		}
+
+		_, err = c.store.UpdateStatus(ctx, filter, change)
+		return err
+	})
+
+	if err != nil {
+		return nil, toStatusError(err, "failed revoking permit")
 	}
 
-	_, err := c.store.Get(ctx, filter)
-	if err != nil && err != mongo.ErrNoDocuments {
-		return false, err
+	return revoked, nil
+}
+
+// CancelPermits updates the permits of the request reqID whose status is one of fromStatuses
+// by change, and notifies the approval service of the cancellation with message, all in a single
+// transaction. An approval request that was not delivered yet is discarded, and message is delayed
+// until any attempt of delivering it that may still be in flight is over, see service.CancelAfter.
+// Returns the number of cancelled permits.
+func (c Controller) CancelPermits(
+	ctx context.Context,
+	reqID string,
+	fromStatuses []string,
+	change service.StatusChange,
+	message service.OutboxMessage,
+) (int64, error) {
+	filter := bson.D{
+		bson.E{
+			Key:   PermitBSONReqIDField,
+			Value: reqID,
+		},
 	}
 
+	var cancelled int64
+	err := c.store.WithTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		cancelled, err = c.store.UpdateStatus(ctx, withStatuses(filter, fromStatuses), change)
+		if err != nil || cancelled == 0 {
+			return err
+		}
+
+		undelivered, err := c.store.GetOutboxMessages(ctx, undeliveredFilter(reqID))
+		if err != nil {
+			return err
+		}
+
+		if _, err := c.store.DeleteOutboxMessages(ctx, undeliveredFilter(reqID)); err != nil {
+			return err
+		}
+
+		_, err = c.store.CreateOutboxMessage(ctx, service.CancelAfter(message, undelivered))
+		return err
+	})
+
+	if err != nil {
+		return 0, toStatusError(err, "failed cancelling permits")
+	}
+
+	return cancelled, nil
+}
+
+// CompleteApprovalRequest records that the approval request of reqID was submitted to the approval
+// service, which gave it approvalID if it's not empty, and removes it from the outbox, in a single transaction.
+func (c Controller) CompleteApprovalRequest(ctx context.Context, reqID string, approvalID string) error {
+	err := c.store.WithTransaction(ctx, func(ctx context.Context) error {
+		err := c.store.SetRequestSubmission(ctx, reqID, service.SubmissionSubmitted, approvalID, "")
+		if err != nil {
+			return err
+		}
+
+		_, err = c.store.DeleteOutboxMessages(ctx, undeliveredFilter(reqID))
+		return err
+	})
+
+	if err == mongo.ErrNoDocuments {
+		return status.Errorf(codes.NotFound, "request %s not found", reqID)
+	}
+
+	if err != nil {
+		return toStatusError(err, "failed completing approval request")
+	}
+
+	return nil
+}
+
+// FailApprovalRequest updates the permits of the request reqID whose status is one of fromStatuses
+// by change, after the approval service rejected their approval request with the reason of change,
+// records the rejection and removes the approval request from the outbox, in a single transaction.
+// Returns the number of updated permits.
+func (c Controller) FailApprovalRequest(
+	ctx context.Context,
+	reqID string,
+	fromStatuses []string,
+	change service.StatusChange,
+) (int64, error) {
+	filter := bson.D{
+		bson.E{
+			Key:   PermitBSONReqIDField,
+			Value: reqID,
+		},
+	}
+
+	var failed int64
+	err := c.store.WithTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		failed, err = c.store.UpdateStatus(ctx, withStatuses(filter, fromStatuses), change)
+		if err != nil {
+			return err
+		}
+
+		err = c.store.SetRequestSubmission(ctx, reqID, service.SubmissionRejected, "", change.Reason)
+		if err != nil {
+			return err
+		}
+
+		_, err = c.store.DeleteOutboxMessages(ctx, undeliveredFilter(reqID))
+		return err
+	})
+
 	if err == mongo.ErrNoDocuments {
-		return false, nil
+		return 0, status.Errorf(codes.NotFound, "request %s not found", reqID)
+	}
+
+	if err != nil {
+		return 0, toStatusError(err, "failed updating permits of rejected approval request")
 	}
 
-	return true, nil
+	return failed, nil
+}
+
+// ExpirePermits updates the permits whose status is one of fromStatuses and whose validity
+// has ended by the time of change, by change, in a single transaction.
+// Returns the permits that were expired as they were before change.
+func (c Controller) ExpirePermits(
+	ctx context.Context,
+	fromStatuses []string,
+	change service.StatusChange,
+) ([]service.Permit, error) {
+	filter := withStatuses(bson.D{
+		bson.E{
+			Key:   PermitBSONExpiresAtField,
+			Value: bson.M{"$lte": change.Time},
+		},
+	}, fromStatuses)
+
+	var expired []service.Permit
+	err := c.store.WithTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		expired, err = c.store.GetAll(ctx, filter)
+		if err != nil || len(expired) == 0 {
+			return err
+		}
+
+		_, err = c.store.UpdateStatus(ctx, filter, change)
+		return err
+	})
 
+	if err != nil {
+		return nil, toStatusError(err, "failed expiring permits")
+	}
+
+	return expired, nil
 }
 
-// UpdatePermitStatus todo
-func (c Controller) UpdatePermitStatus(ctx context.Context, reqID string, status string) (bool, error) {
-	err := c.store.UpdateStatus(ctx, reqID, status)
+// ClaimOutboxMessage claims the outbox message that is due the earliest at now for lease,
+// if there are no due messages it returns a codes.NotFound status error.
+func (c Controller) ClaimOutboxMessage(ctx context.Context, now time.Time, lease time.Duration) (service.OutboxMessage, error) {
+	message, err := c.store.ClaimOutboxMessage(ctx, now, lease)
+	if err == mongo.ErrNoDocuments {
+		return service.OutboxMessage{}, status.Error(codes.NotFound, "no due outbox messages")
+	}
+
 	if err != nil {
-		return false, fmt.Errorf("updating status %v", err)
+		return service.OutboxMessage{}, toStatusError(err, "failed claiming outbox message")
+	}
+
+	return message, nil
+}
+
+// RetryOutboxMessage schedules another delivery attempt of the outbox message id at nextAttemptAt.
+func (c Controller) RetryOutboxMessage(ctx context.Context, id string, nextAttemptAt time.Time, lastError string) error {
+	return c.store.RetryOutboxMessage(ctx, id, nextAttemptAt, lastError)
+}
+
+// DeleteOutboxMessage removes the delivered outbox message id.
+func (c Controller) DeleteOutboxMessage(ctx context.Context, id string) error {
+	return c.store.DeleteOutboxMessage(ctx, id)
+}
+
+// withStatuses returns filter narrowed down to permits whose status is one of statuses.
+func withStatuses(filter bson.D, statuses []string) bson.D {
+	return append(filter, bson.E{
+		Key:   PermitBSONStatusField,
+		Value: bson.M{"$in": statuses},
+	})
+}
+
+// undeliveredFilter returns the filter of the outbox messages of the approval request of reqID
+// which were not delivered yet.
+func undeliveredFilter(reqID string) bson.D {
+	return bson.D{
+		bson.E{
+			Key:   OutboxBSONReqIDField,
+			Value: reqID,
+		},
+		bson.E{
+			Key:   OutboxBSONKindField,
+			Value: bson.M{"$in": bson.A{service.OutboxKindCreate, nil}},
+		},
+	}
+}
+
+// toStatusError converts err returned from the store to a status error with msg prefixed to its message,
+// transient errors which are safe to retry are converted to codes.Unavailable, and duplicate key
+// errors are converted to codes.AlreadyExists.
+func toStatusError(err error, msg string) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	code := codes.Internal
+	switch e := err.(type) {
+	case mongo.CommandError:
+		if e.HasErrorLabel("TransientTransactionError") || e.HasErrorLabel("UnknownTransactionCommitResult") {
+			code = codes.Unavailable
+		}
+
+		if e.Code == duplicateKeyCode {
+			code = codes.AlreadyExists
+		}
+	case mongo.WriteException:
+		for _, writeErr := range e.WriteErrors {
+			if writeErr.Code == duplicateKeyCode {
+				code = codes.AlreadyExists
+			}
+		}
+	case mongo.BulkWriteException:
+		for _, writeErr := range e.WriteErrors {
+			if writeErr.Code == duplicateKeyCode {
+				code = codes.AlreadyExists
+			}
+		}
+	default:
+		switch err {
+		case context.DeadlineExceeded:
+			code = codes.DeadlineExceeded
+		case context.Canceled:
+			code = codes.Canceled
+		}
 	}
 
-	return true, nil
+	return status.Errorf(code, "%s: %v", msg, err)
 }
@@ -0,0 +1,49 @@
+package mongodb
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// legacyPermitIndexName is the name of the unique index of the fileID and userID of permits,
+// which kept only a single permit of each user to each file.
+const legacyPermitIndexName = "fileID_1_userID_1"
+
+// indexNotFoundCode is the error code of dropping an index that doesn't exist.
+const indexNotFoundCode = 27
+
+// migratePermits migrates the permits collection from a single permit of each user to each file,
+// to a permit of each user of each request, by dropping the legacy unique index of fileID and userID.
+// The existing permits are kept as the permits of the requests they were last created by.
+// It's a no-op if the collection was already migrated, including by another instance of the
+// service migrating it concurrently.
+func migratePermits(ctx context.Context, collection *mongo.Collection) error {
+	cursor, err := collection.Indexes().List(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		index := struct {
+			Name string `bson:"name"`
+		}{}
+
+		if err := cursor.Decode(&index); err != nil {
+			return err
+		}
+
+		if index.Name == legacyPermitIndexName {
+			_, err := collection.Indexes().DropOne(ctx, legacyPermitIndexName)
+			if cmdErr, ok := err.(mongo.CommandError); ok && cmdErr.Code == indexNotFoundCode {
+				return nil
+			}
+
+			return err
+		}
+	}
+
+	return cursor.Err()
+}
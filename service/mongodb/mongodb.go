@@ -2,8 +2,10 @@ package mongodb
 
 import (
 	"fmt"
+	"time"
 
 	pb "github.com/meateam/permit-service/proto"
+	"github.com/meateam/permit-service/service"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
@@ -14,6 +16,30 @@ type BSON struct {
 	Status string             `bson:"status,omitempty"`
 	UserID string             `bson:"userID,omitempty"`
 	ReqID  string             `bson:"reqID,omitempty"`
+
+	ExpiresAt time.Time          `bson:"expiresAt,omitempty"`
+	CreatedAt time.Time          `bson:"createdAt,omitempty"`
+	UpdatedAt time.Time          `bson:"updatedAt,omitempty"`
+	DecidedAt time.Time          `bson:"decidedAt,omitempty"`
+	History   []StatusChangeBSON `bson:"history,omitempty"`
+}
+
+// StatusChangeBSON is the struct that represents an entry of a permit's status history as it's stored.
+type StatusChangeBSON struct {
+	Status string    `bson:"status"`
+	Actor  string    `bson:"actor,omitempty"`
+	Reason string    `bson:"reason,omitempty"`
+	Time   time.Time `bson:"time"`
+}
+
+// newStatusChangeBSON returns the StatusChangeBSON of change.
+func newStatusChangeBSON(change service.StatusChange) StatusChangeBSON {
+	return StatusChangeBSON{
+		Status: change.Status,
+		Actor:  change.Actor,
+		Reason: change.Reason,
+		Time:   change.Time,
+	}
 }
 
 // GetID returns the string value of the b.ID.
@@ -74,6 +100,95 @@ func (b *BSON) SetStatus(status string) error {
 	return nil
 }
 
+// GetExpiresAt returns b.ExpiresAt.
+func (b BSON) GetExpiresAt() time.Time {
+	return b.ExpiresAt
+}
+
+// SetExpiresAt sets b.ExpiresAt to expiresAt.
+func (b *BSON) SetExpiresAt(expiresAt time.Time) error {
+	if b == nil {
+		panic("b == nil")
+	}
+
+	b.ExpiresAt = expiresAt
+	return nil
+}
+
+// GetCreatedAt returns b.CreatedAt.
+func (b BSON) GetCreatedAt() time.Time {
+	return b.CreatedAt
+}
+
+// SetCreatedAt sets b.CreatedAt to createdAt.
+func (b *BSON) SetCreatedAt(createdAt time.Time) error {
+	if b == nil {
+		panic("b == nil")
+	}
+
+	b.CreatedAt = createdAt
+	return nil
+}
+
+// GetUpdatedAt returns b.UpdatedAt.
+func (b BSON) GetUpdatedAt() time.Time {
+	return b.UpdatedAt
+}
+
+// SetUpdatedAt sets b.UpdatedAt to updatedAt.
+func (b *BSON) SetUpdatedAt(updatedAt time.Time) error {
+	if b == nil {
+		panic("b == nil")
+	}
+
+	b.UpdatedAt = updatedAt
+	return nil
+}
+
+// GetDecidedAt returns b.DecidedAt.
+func (b BSON) GetDecidedAt() time.Time {
+	return b.DecidedAt
+}
+
+// SetDecidedAt sets b.DecidedAt to decidedAt.
+func (b *BSON) SetDecidedAt(decidedAt time.Time) error {
+	if b == nil {
+		panic("b == nil")
+	}
+
+	b.DecidedAt = decidedAt
+	return nil
+}
+
+// GetHistory returns the status history of b.
+func (b BSON) GetHistory() []service.StatusChange {
+	history := make([]service.StatusChange, 0, len(b.History))
+	for _, change := range b.History {
+		history = append(history, service.StatusChange{
+			Status: change.Status,
+			Actor:  change.Actor,
+			Reason: change.Reason,
+			Time:   change.Time,
+		})
+	}
+
+	return history
+}
+
+// AppendHistory appends change to the status history of b.
+func (b *BSON) AppendHistory(change service.StatusChange) error {
+	if b == nil {
+		panic("b == nil")
+	}
+
+	if change.Status == "" {
+		return fmt.Errorf("status is required")
+	}
+
+	b.History = append(b.History, newStatusChangeBSON(change))
+	return nil
+}
+
 // GetUserID returns b.UserID.
 func (b BSON) GetUserID() string {
 	return b.UserID
@@ -118,6 +233,20 @@ func (b BSON) MarshalProto(permit *pb.PermitObject) error {
 	permit.FileID = b.GetFileID()
 	permit.UserID = b.GetUserID()
 	permit.Status = b.GetStatus()
+	permit.CreatedAt = service.UnixMillis(b.GetCreatedAt())
+	permit.UpdatedAt = service.UnixMillis(b.GetUpdatedAt())
+	permit.DecidedAt = service.UnixMillis(b.GetDecidedAt())
+	permit.ExpiresAt = service.UnixMillis(b.GetExpiresAt())
+
+	permit.History = make([]*pb.StatusChange, 0, len(b.History))
+	for _, change := range b.GetHistory() {
+		changeProto := &pb.StatusChange{}
+		if err := change.MarshalProto(changeProto); err != nil {
+			return err
+		}
+
+		permit.History = append(permit.History, changeProto)
+	}
 
 	return nil
 }
@@ -0,0 +1,58 @@
+package mongodb
+
+import (
+	"time"
+
+	"github.com/meateam/permit-service/service"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// OutboxBSON is the struct that represents an outbox message as it's stored.
+type OutboxBSON struct {
+	ID            primitive.ObjectID      `bson:"_id,omitempty"`
+	Kind          string                  `bson:"kind,omitempty"`
+	ReqID         string                  `bson:"reqID"`
+	Request       service.ApprovalReqType `bson:"request"`
+	Attempts      int                     `bson:"attempts"`
+	NextAttemptAt time.Time               `bson:"nextAttemptAt"`
+	LastError     string                  `bson:"lastError,omitempty"`
+	CreatedAt     time.Time               `bson:"createdAt"`
+}
+
+// newOutboxBSON returns the OutboxBSON of message.
+func newOutboxBSON(message service.OutboxMessage) OutboxBSON {
+	return OutboxBSON{
+		Kind:          message.Kind,
+		ReqID:         message.ReqID,
+		Request:       message.Request,
+		Attempts:      message.Attempts,
+		NextAttemptAt: message.NextAttemptAt,
+		LastError:     message.LastError,
+		CreatedAt:     message.CreatedAt,
+	}
+}
+
+// OutboxMessage returns the service.OutboxMessage that b represents.
+func (b OutboxBSON) OutboxMessage() service.OutboxMessage {
+	id := ""
+	if !b.ID.IsZero() {
+		id = b.ID.Hex()
+	}
+
+	// Messages stored before kinds were introduced are all approval requests.
+	kind := b.Kind
+	if kind == "" {
+		kind = service.OutboxKindCreate
+	}
+
+	return service.OutboxMessage{
+		ID:            id,
+		Kind:          kind,
+		ReqID:         b.ReqID,
+		Request:       b.Request,
+		Attempts:      b.Attempts,
+		NextAttemptAt: b.NextAttemptAt,
+		LastError:     b.LastError,
+		CreatedAt:     b.CreatedAt,
+	}
+}
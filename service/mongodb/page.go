@@ -0,0 +1,26 @@
+package mongodb
+
+import (
+	"github.com/meateam/permit-service/service"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// withPageToken returns filter narrowed down to documents after the page token pageToken,
+// pages are ordered from the newest document to the oldest.
+// Returns service.ErrInvalidPageToken if pageToken is invalid.
+func withPageToken(filter bson.D, pageToken string) (bson.D, error) {
+	if pageToken == "" {
+		return filter, nil
+	}
+
+	lastID, err := primitive.ObjectIDFromHex(pageToken)
+	if err != nil {
+		return nil, service.ErrInvalidPageToken
+	}
+
+	return append(filter, bson.E{
+		Key:   MongoObjectIDField,
+		Value: bson.M{"$lt": lastID},
+	}), nil
+}
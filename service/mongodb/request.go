@@ -0,0 +1,204 @@
+package mongodb
+
+import (
+	"time"
+
+	pb "github.com/meateam/permit-service/proto"
+	"github.com/meateam/permit-service/service"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// RequestBSON is the struct that represents a permit request as it's stored.
+type RequestBSON struct {
+	ID             primitive.ObjectID `bson:"_id,omitempty"`
+	ReqID          string             `bson:"reqID"`
+	FileID         string             `bson:"fileID"`
+	FileName       string             `bson:"fileName,omitempty"`
+	SharerID       string             `bson:"sharerID"`
+	Approvers      []string           `bson:"approvers"`
+	Classification string             `bson:"classification,omitempty"`
+	Info           string             `bson:"info,omitempty"`
+	Users          []UserBSON         `bson:"users"`
+	CreatedAt      time.Time          `bson:"createdAt"`
+	ExpiresAt      time.Time          `bson:"expiresAt,omitempty"`
+	ApprovalID     string             `bson:"approvalID,omitempty"`
+	IdempotencyKey string             `bson:"idempotencyKey,omitempty"`
+
+	Results           []UserResultBSON `bson:"results,omitempty"`
+	Submission        string           `bson:"submission,omitempty"`
+	SubmissionMessage string           `bson:"submissionMessage,omitempty"`
+}
+
+// UserResultBSON is the struct that represents the result of a user of a permit request as it's stored.
+type UserResultBSON struct {
+	UserID string `bson:"userID"`
+	Result string `bson:"result"`
+	Status string `bson:"status,omitempty"`
+	Reason string `bson:"reason,omitempty"`
+}
+
+// RequestPermitsBSON is the struct that represents a permit request joined with its permits.
+type RequestPermitsBSON struct {
+	RequestBSON `bson:",inline"`
+	Permits     []*BSON `bson:"permits"`
+}
+
+// RequestPermits returns the service.RequestPermits that b represents.
+func (b *RequestPermitsBSON) RequestPermits() service.RequestPermits {
+	permits := make([]service.Permit, 0, len(b.Permits))
+	for _, permit := range b.Permits {
+		permits = append(permits, permit)
+	}
+
+	return service.RequestPermits{Request: &b.RequestBSON, Permits: permits}
+}
+
+// UserBSON is the struct that represents a user of a permit request as it's stored.
+type UserBSON struct {
+	ID       string `bson:"id"`
+	FullName string `bson:"fullName,omitempty"`
+}
+
+// newRequestBSON returns the RequestBSON of the approval request created at createdAt.
+func newRequestBSON(request service.ApprovalReqType, createdAt time.Time) *RequestBSON {
+	users := make([]UserBSON, 0, len(request.To))
+	for _, user := range request.To {
+		users = append(users, UserBSON{ID: user.ID, FullName: user.Name})
+	}
+
+	results := make([]UserResultBSON, 0, len(request.Results))
+	for _, result := range request.Results {
+		results = append(results, UserResultBSON(result))
+	}
+
+	return &RequestBSON{
+		ReqID:          request.ID,
+		FileID:         request.FileID,
+		FileName:       request.FileName,
+		SharerID:       request.From,
+		Approvers:      request.Approvers,
+		Classification: request.Classification,
+		Info:           request.Info,
+		Users:          users,
+		CreatedAt:      createdAt,
+		ExpiresAt:      service.FromUnixMillis(request.ExpiresAt),
+		IdempotencyKey: request.IdempotencyKey,
+		Results:        results,
+		Submission:     service.SubmissionQueued,
+	}
+}
+
+// GetID returns the string value of the b.ID.
+func (b RequestBSON) GetID() string {
+	if b.ID.IsZero() {
+		return ""
+	}
+
+	return b.ID.Hex()
+}
+
+// GetReqID returns b.ReqID.
+func (b RequestBSON) GetReqID() string {
+	return b.ReqID
+}
+
+// GetFileID returns b.FileID.
+func (b RequestBSON) GetFileID() string {
+	return b.FileID
+}
+
+// GetFileName returns b.FileName.
+func (b RequestBSON) GetFileName() string {
+	return b.FileName
+}
+
+// GetSharerID returns b.SharerID.
+func (b RequestBSON) GetSharerID() string {
+	return b.SharerID
+}
+
+// GetApprovers returns b.Approvers.
+func (b RequestBSON) GetApprovers() []string {
+	return b.Approvers
+}
+
+// GetClassification returns b.Classification.
+func (b RequestBSON) GetClassification() string {
+	return b.Classification
+}
+
+// GetInfo returns b.Info.
+func (b RequestBSON) GetInfo() string {
+	return b.Info
+}
+
+// GetUsers returns the users of b.
+func (b RequestBSON) GetUsers() []service.UserType {
+	users := make([]service.UserType, 0, len(b.Users))
+	for _, user := range b.Users {
+		users = append(users, service.UserType{ID: user.ID, Name: user.FullName})
+	}
+
+	return users
+}
+
+// GetCreatedAt returns b.CreatedAt.
+func (b RequestBSON) GetCreatedAt() time.Time {
+	return b.CreatedAt
+}
+
+// GetExpiresAt returns b.ExpiresAt.
+func (b RequestBSON) GetExpiresAt() time.Time {
+	return b.ExpiresAt
+}
+
+// GetApprovalID returns b.ApprovalID.
+func (b RequestBSON) GetApprovalID() string {
+	return b.ApprovalID
+}
+
+// GetIdempotencyKey returns b.IdempotencyKey.
+func (b RequestBSON) GetIdempotencyKey() string {
+	return b.IdempotencyKey
+}
+
+// GetResults returns the results of the users of b.
+func (b RequestBSON) GetResults() []service.UserResult {
+	results := make([]service.UserResult, 0, len(b.Results))
+	for _, result := range b.Results {
+		results = append(results, service.UserResult(result))
+	}
+
+	return results
+}
+
+// GetSubmission returns b.Submission.
+func (b RequestBSON) GetSubmission() string {
+	return b.Submission
+}
+
+// GetSubmissionMessage returns b.SubmissionMessage.
+func (b RequestBSON) GetSubmissionMessage() string {
+	return b.SubmissionMessage
+}
+
+// MarshalProto marshals b into a permit request.
+func (b RequestBSON) MarshalProto(request *pb.PermitRequestObject) error {
+	request.ReqID = b.GetReqID()
+	request.FileID = b.GetFileID()
+	request.FileName = b.GetFileName()
+	request.SharerID = b.GetSharerID()
+	request.Approvers = b.GetApprovers()
+	request.Classification = b.GetClassification()
+	request.Info = b.GetInfo()
+	request.CreatedAt = service.UnixMillis(b.GetCreatedAt())
+	request.ExpiresAt = service.UnixMillis(b.GetExpiresAt())
+	request.ApprovalID = b.GetApprovalID()
+
+	request.Users = make([]*pb.User, 0, len(b.Users))
+	for _, user := range b.Users {
+		request.Users = append(request.Users, &pb.User{Id: user.ID, FullName: user.FullName})
+	}
+
+	return nil
+}
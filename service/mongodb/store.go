@@ -3,9 +3,11 @@ package mongodb
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/meateam/permit-service/service"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.mongodb.org/mongo-driver/mongo/readpref"
@@ -15,6 +17,12 @@ const (
 	// PermitCollectionName is the name of the permits collection.
 	PermitCollectionName = "permits"
 
+	// RequestCollectionName is the name of the permit requests collection.
+	RequestCollectionName = "requests"
+
+	// OutboxCollectionName is the name of the collection of approval requests waiting for delivery.
+	OutboxCollectionName = "outbox"
+
 	// MongoObjectIDField is the default mongodb unique key.
 	MongoObjectIDField = "_id"
 
@@ -29,6 +37,60 @@ const (
 
 	// PermitBSONStatusField is the name of the reqID field in BSON.
 	PermitBSONStatusField = "status"
+
+	// PermitBSONExpiresAtField is the name of the expiresAt field in BSON.
+	PermitBSONExpiresAtField = "expiresAt"
+
+	// PermitBSONCreatedAtField is the name of the createdAt field in BSON.
+	PermitBSONCreatedAtField = "createdAt"
+
+	// PermitBSONUpdatedAtField is the name of the updatedAt field in BSON.
+	PermitBSONUpdatedAtField = "updatedAt"
+
+	// PermitBSONDecidedAtField is the name of the decidedAt field in BSON.
+	PermitBSONDecidedAtField = "decidedAt"
+
+	// PermitBSONHistoryField is the name of the history field in BSON.
+	PermitBSONHistoryField = "history"
+
+	// RequestBSONReqIDField is the name of the reqID field in the request BSON.
+	RequestBSONReqIDField = "reqID"
+
+	// RequestBSONSharerIDField is the name of the sharerID field in the request BSON.
+	RequestBSONSharerIDField = "sharerID"
+
+	// RequestBSONApproversField is the name of the approvers field in the request BSON.
+	RequestBSONApproversField = "approvers"
+
+	// RequestBSONApprovalIDField is the name of the approvalID field in the request BSON.
+	RequestBSONApprovalIDField = "approvalID"
+
+	// RequestBSONIdempotencyKeyField is the name of the idempotencyKey field in the request BSON.
+	RequestBSONIdempotencyKeyField = "idempotencyKey"
+
+	// RequestBSONSubmissionField is the name of the submission field in the request BSON.
+	RequestBSONSubmissionField = "submission"
+
+	// RequestBSONSubmissionMessageField is the name of the submissionMessage field in the request BSON.
+	RequestBSONSubmissionMessageField = "submissionMessage"
+
+	// RequestPermitsBSONPermitsField is the name of the field the permits of a request are joined to.
+	RequestPermitsBSONPermitsField = "permits"
+
+	// OutboxBSONReqIDField is the name of the reqID field in the outbox BSON.
+	OutboxBSONReqIDField = "reqID"
+
+	// OutboxBSONKindField is the name of the kind field in the outbox BSON.
+	OutboxBSONKindField = "kind"
+
+	// OutboxBSONAttemptsField is the name of the attempts field in the outbox BSON.
+	OutboxBSONAttemptsField = "attempts"
+
+	// OutboxBSONNextAttemptAtField is the name of the nextAttemptAt field in the outbox BSON.
+	OutboxBSONNextAttemptAtField = "nextAttemptAt"
+
+	// OutboxBSONLastErrorField is the name of the lastError field in the outbox BSON.
+	OutboxBSONLastErrorField = "lastError"
 )
 
 // MongoStore holds the mongodb database and implements Store interface.
@@ -36,16 +98,22 @@ type MongoStore struct {
 	DB *mongo.Database
 }
 
+var _ service.Store = MongoStore{}
+
 // newMongoStore returns a new store.
 func newMongoStore(db *mongo.Database) (MongoStore, error) {
 	collection := db.Collection(PermitCollectionName)
 	indexes := collection.Indexes()
 
-	// The index of the collection is the combination of fileID and userID
+	if err := migratePermits(context.Background(), collection); err != nil {
+		return MongoStore{}, err
+	}
+
+	// A request has a single permit of each of its users.
 	indexModel := mongo.IndexModel{
 		Keys: bson.D{
 			bson.E{
-				Key:   PermitBSONFileIDField,
+				Key:   PermitBSONReqIDField,
 				Value: 1,
 			},
 			bson.E{
@@ -61,6 +129,173 @@ func newMongoStore(db *mongo.Database) (MongoStore, error) {
 		return MongoStore{}, err
 	}
 
+	// The effective permit of a user to a file is derived from all of the permits of the user to the file.
+	fileUserIndexModel := mongo.IndexModel{
+		Keys: bson.D{
+			bson.E{
+				Key:   PermitBSONFileIDField,
+				Value: 1,
+			},
+			bson.E{
+				Key:   PermitBSONUserIDField,
+				Value: 1,
+			},
+			bson.E{
+				Key:   MongoObjectIDField,
+				Value: -1,
+			},
+		},
+	}
+
+	_, err = indexes.CreateOne(context.Background(), fileUserIndexModel)
+	if err != nil {
+		return MongoStore{}, err
+	}
+
+	// A request is identified by its reqID.
+	requestIndexModel := mongo.IndexModel{
+		Keys: bson.D{
+			bson.E{
+				Key:   RequestBSONReqIDField,
+				Value: 1,
+			},
+		},
+		Options: options.Index().SetUnique(true),
+	}
+
+	_, err = db.Collection(RequestCollectionName).Indexes().CreateOne(context.Background(), requestIndexModel)
+	if err != nil {
+		return MongoStore{}, err
+	}
+
+	// Permits are listed by their user, from the newest to the oldest.
+	userIndexModel := mongo.IndexModel{
+		Keys: bson.D{
+			bson.E{
+				Key:   PermitBSONUserIDField,
+				Value: 1,
+			},
+			bson.E{
+				Key:   MongoObjectIDField,
+				Value: -1,
+			},
+		},
+	}
+
+	_, err = indexes.CreateOne(context.Background(), userIndexModel)
+	if err != nil {
+		return MongoStore{}, err
+	}
+
+	// Permits are polled for changes when change streams are unavailable.
+	updatedAtIndexModel := mongo.IndexModel{
+		Keys: bson.D{
+			bson.E{
+				Key:   PermitBSONUpdatedAtField,
+				Value: 1,
+			},
+		},
+	}
+
+	_, err = indexes.CreateOne(context.Background(), updatedAtIndexModel)
+	if err != nil {
+		return MongoStore{}, err
+	}
+
+	// Permits are swept for the ones whose validity has ended.
+	expiryIndexModel := mongo.IndexModel{
+		Keys: bson.D{
+			bson.E{
+				Key:   PermitBSONExpiresAtField,
+				Value: 1,
+			},
+		},
+		Options: options.Index().SetSparse(true),
+	}
+
+	_, err = indexes.CreateOne(context.Background(), expiryIndexModel)
+	if err != nil {
+		return MongoStore{}, err
+	}
+
+	// Requests are listed by their sharer, from the newest to the oldest.
+	sharerIndexModel := mongo.IndexModel{
+		Keys: bson.D{
+			bson.E{
+				Key:   RequestBSONSharerIDField,
+				Value: 1,
+			},
+			bson.E{
+				Key:   MongoObjectIDField,
+				Value: -1,
+			},
+		},
+	}
+
+	_, err = db.Collection(RequestCollectionName).Indexes().CreateOne(context.Background(), sharerIndexModel)
+	if err != nil {
+		return MongoStore{}, err
+	}
+
+	// Requests are listed by their approvers, from the newest to the oldest.
+	approversIndexModel := mongo.IndexModel{
+		Keys: bson.D{
+			bson.E{
+				Key:   RequestBSONApproversField,
+				Value: 1,
+			},
+			bson.E{
+				Key:   MongoObjectIDField,
+				Value: -1,
+			},
+		},
+	}
+
+	_, err = db.Collection(RequestCollectionName).Indexes().CreateOne(context.Background(), approversIndexModel)
+	if err != nil {
+		return MongoStore{}, err
+	}
+
+	// A sharer's requests are identified by their idempotency key, if they were created with one.
+	idempotencyIndexModel := mongo.IndexModel{
+		Keys: bson.D{
+			bson.E{
+				Key:   RequestBSONSharerIDField,
+				Value: 1,
+			},
+			bson.E{
+				Key:   RequestBSONIdempotencyKeyField,
+				Value: 1,
+			},
+		},
+		Options: options.Index().SetUnique(true).SetPartialFilterExpression(bson.D{
+			bson.E{
+				Key:   RequestBSONIdempotencyKeyField,
+				Value: bson.M{"$exists": true},
+			},
+		}),
+	}
+
+	_, err = db.Collection(RequestCollectionName).Indexes().CreateOne(context.Background(), idempotencyIndexModel)
+	if err != nil {
+		return MongoStore{}, err
+	}
+
+	// The outbox is polled for the messages which are due for delivery.
+	outboxIndexModel := mongo.IndexModel{
+		Keys: bson.D{
+			bson.E{
+				Key:   OutboxBSONNextAttemptAtField,
+				Value: 1,
+			},
+		},
+	}
+
+	_, err = db.Collection(OutboxCollectionName).Indexes().CreateOne(context.Background(), outboxIndexModel)
+	if err != nil {
+		return MongoStore{}, err
+	}
+
 	return MongoStore{DB: db}, nil
 }
 
@@ -96,10 +331,10 @@ func (s MongoStore) GetAll(ctx context.Context, filter interface{}) ([]service.P
 
 	// cur is the cursor for iterating over the permits.
 	cur, err := collection.Find(ctx, filter)
-	defer cur.Close(ctx)
 	if err != nil {
 		return nil, err
 	}
+	defer cur.Close(ctx)
 
 	permits := []service.Permit{}
 	for cur.Next(ctx) {
@@ -119,33 +354,117 @@ func (s MongoStore) GetAll(ctx context.Context, filter interface{}) ([]service.P
 	return permits, nil
 }
 
+// GetPage finds up to limit permits that match filter, from the newest permit to the oldest,
+// if successful returns the permits, and a nil error,
+// otherwise returns nil and non-nil error if any occurred.
+func (s MongoStore) GetPage(ctx context.Context, filter interface{}, limit int64) ([]service.Permit, error) {
+	collection := s.DB.Collection(PermitCollectionName)
+
+	opts := options.Find().
+		SetSort(bson.D{bson.E{Key: MongoObjectIDField, Value: -1}}).
+		SetLimit(limit)
+
+	cur, err := collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	permits := []service.Permit{}
+	for cur.Next(ctx) {
+		permit := &BSON{}
+		if err := cur.Decode(permit); err != nil {
+			return nil, err
+		}
+
+		permits = append(permits, permit)
+	}
+
+	if err := cur.Err(); err != nil {
+		return nil, err
+	}
+
+	return permits, nil
+}
+
+// Watch opens a change stream on the permits collection with pipeline,
+// the changed permits are looked up and included in full in the change events.
+func (s MongoStore) Watch(ctx context.Context, pipeline interface{}) (*mongo.ChangeStream, error) {
+	collection := s.DB.Collection(PermitCollectionName)
+
+	return collection.Watch(ctx, pipeline, options.ChangeStream().SetFullDocument(options.UpdateLookup))
+}
+
 // Create creates a permit of a file to a user,
-// If permit already exists then its updated to have the permit values,
+// If a permit of the same request and user already exists then its updated to have the permit values,
 // If successful returns the permit and a nil error,
 // otherwise returns empty string and non-nil error if any occurred.
 func (s MongoStore) Create(ctx context.Context, permit service.Permit) (service.Permit, error) {
 	collection := s.DB.Collection(PermitCollectionName)
+	filter, update, err := permitUpsert(permit)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+	result := collection.FindOneAndUpdate(ctx, filter, update, opts)
+	newPermit := &BSON{}
+	err = result.Decode(newPermit)
+	if err != nil {
+		return nil, err
+	}
+
+	return newPermit, nil
+}
+
+// CreateMany creates all of permits in a single ordered bulk write,
+// a permit of the same request and user that already exists is updated to have the permit values.
+// If any of permits is invalid then none of them are written.
+// Use it in WithTransaction for all of the permits to be written or none of them.
+func (s MongoStore) CreateMany(ctx context.Context, permits []service.Permit) error {
+	collection := s.DB.Collection(PermitCollectionName)
+	if len(permits) == 0 {
+		return fmt.Errorf("at least one permit is required")
+	}
+
+	models := make([]mongo.WriteModel, 0, len(permits))
+	for _, permit := range permits {
+		filter, update, err := permitUpsert(permit)
+		if err != nil {
+			return err
+		}
+
+		models = append(models, mongo.NewUpdateOneModel().SetFilter(filter).SetUpdate(update).SetUpsert(true))
+	}
+
+	_, err := collection.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(true))
+	return err
+}
+
+// permitUpsert returns the filter and update of upserting permit,
+// or a non-nil error if permit is missing a required field.
+func permitUpsert(permit service.Permit) (bson.D, bson.D, error) {
 	fileID := permit.GetFileID()
 	if fileID == "" {
-		return nil, fmt.Errorf("fileID is required")
+		return nil, nil, fmt.Errorf("fileID is required")
 	}
 
 	userID := permit.GetUserID()
 	if userID == "" {
-		return nil, fmt.Errorf("userID is required")
+		return nil, nil, fmt.Errorf("userID is required")
 	}
 
 	reqID := permit.GetReqID()
 	if reqID == "" {
-		return nil, fmt.Errorf("reqID is required")
+		return nil, nil, fmt.Errorf("reqID is required")
 	}
 
 	status := permit.GetStatus()
 
 	filter := bson.D{
 		bson.E{
-			Key:   PermitBSONFileIDField,
-			Value: fileID,
+			Key:   PermitBSONReqIDField,
+			Value: reqID,
 		},
 		bson.E{
 			Key:   PermitBSONUserIDField,
@@ -170,63 +489,359 @@ func (s MongoStore) Create(ctx context.Context, permit service.Permit) (service.
 			Key:   PermitBSONStatusField,
 			Value: status,
 		},
+		bson.E{
+			Key:   PermitBSONCreatedAtField,
+			Value: permit.GetCreatedAt(),
+		},
+		bson.E{
+			Key:   PermitBSONUpdatedAtField,
+			Value: permit.GetUpdatedAt(),
+		},
+	}
+
+	unset := bson.M{PermitBSONDecidedAtField: ""}
+	if expiresAt := permit.GetExpiresAt(); expiresAt.IsZero() {
+		unset[PermitBSONExpiresAtField] = ""
+	} else {
+		permitUpdate = append(permitUpdate, bson.E{Key: PermitBSONExpiresAtField, Value: expiresAt})
+	}
+
+	history := make([]StatusChangeBSON, 0, len(permit.GetHistory()))
+	for _, change := range permit.GetHistory() {
+		history = append(history, newStatusChangeBSON(change))
 	}
 
+	// An existing permit of the request keeps its history, and is undecided again until it's decided.
 	update := bson.D{
 		bson.E{
 			Key:   "$set",
 			Value: permitUpdate,
 		},
+		bson.E{
+			Key:   "$unset",
+			Value: unset,
+		},
+		bson.E{
+			Key:   "$push",
+			Value: bson.M{PermitBSONHistoryField: bson.M{"$each": history}},
+		},
 	}
 
-	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
-	result := collection.FindOneAndUpdate(ctx, filter, update, opts)
-	newPermit := &BSON{}
-	err := result.Decode(newPermit)
+	return filter, update, nil
+}
+
+// CreateRequest stores the permit request,
+// returns a non-nil error if a request with the same reqID already exists or any other error occurred.
+func (s MongoStore) CreateRequest(ctx context.Context, request service.PermitRequest) error {
+	collection := s.DB.Collection(RequestCollectionName)
+	if request.GetReqID() == "" {
+		return fmt.Errorf("reqID is required")
+	}
+
+	_, err := collection.InsertOne(ctx, request)
+	return err
+}
+
+// SetRequestSubmission sets the submission of the approval request of the permit request reqID,
+// along with the approvalID the approval service gave it or the message it was rejected with, if not empty.
+// Returns mongo.ErrNoDocuments if there is no such request, or a non-nil error if any other error occurred.
+func (s MongoStore) SetRequestSubmission(
+	ctx context.Context,
+	reqID string,
+	submission string,
+	approvalID string,
+	message string,
+) error {
+	collection := s.DB.Collection(RequestCollectionName)
+
+	filter := bson.D{
+		bson.E{
+			Key:   RequestBSONReqIDField,
+			Value: reqID,
+		},
+	}
+
+	set := bson.D{
+		bson.E{
+			Key:   RequestBSONSubmissionField,
+			Value: submission,
+		},
+	}
+
+	if approvalID != "" {
+		set = append(set, bson.E{Key: RequestBSONApprovalIDField, Value: approvalID})
+	}
+
+	if message != "" {
+		set = append(set, bson.E{Key: RequestBSONSubmissionMessageField, Value: message})
+	}
+
+	result, err := collection.UpdateOne(ctx, filter, bson.D{bson.E{Key: "$set", Value: set}})
 	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+
+	return nil
+}
+
+// GetRequest finds one permit request that matches filter,
+// if successful returns the request, and a nil error,
+// if the request is not found it would return nil and mongo.ErrNoDocuments error,
+// otherwise returns nil and non-nil error if any occurred.
+func (s MongoStore) GetRequest(ctx context.Context, filter interface{}) (service.PermitRequest, error) {
+	collection := s.DB.Collection(RequestCollectionName)
+
+	request := &RequestBSON{}
+	if err := collection.FindOne(ctx, filter).Decode(request); err != nil {
 		return nil, err
 	}
 
-	return newPermit, nil
+	return request, nil
 }
 
-// UpdateStatus updates all permits with a given reqID to a given status
-func (s MongoStore) UpdateStatus(ctx context.Context, reqID string, status string) error {
+// GetRequestsPage finds up to limit permit requests that match filter, from the newest request
+// to the oldest, each joined with its permits. If permitStatuses is not empty, only requests with
+// at least one permit whose status is one of permitStatuses are found.
+// If successful returns the requests, and a nil error,
+// otherwise returns nil and non-nil error if any occurred.
+func (s MongoStore) GetRequestsPage(
+	ctx context.Context,
+	filter interface{},
+	permitStatuses []string,
+	limit int64,
+) ([]service.RequestPermits, error) {
+	collection := s.DB.Collection(RequestCollectionName)
+
+	pipeline := mongo.Pipeline{
+		bson.D{bson.E{Key: "$match", Value: filter}},
+		bson.D{bson.E{Key: "$sort", Value: bson.D{bson.E{Key: MongoObjectIDField, Value: -1}}}},
+		bson.D{bson.E{Key: "$lookup", Value: bson.D{
+			bson.E{Key: "from", Value: PermitCollectionName},
+			bson.E{Key: "localField", Value: RequestBSONReqIDField},
+			bson.E{Key: "foreignField", Value: PermitBSONReqIDField},
+			bson.E{Key: "as", Value: RequestPermitsBSONPermitsField},
+		}}},
+	}
+
+	if len(permitStatuses) > 0 {
+		pipeline = append(pipeline, bson.D{bson.E{Key: "$match", Value: bson.M{
+			RequestPermitsBSONPermitsField + "." + PermitBSONStatusField: bson.M{"$in": permitStatuses},
+		}}})
+	}
+
+	pipeline = append(pipeline, bson.D{bson.E{Key: "$limit", Value: limit}})
+
+	cur, err := collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	requests := []service.RequestPermits{}
+	for cur.Next(ctx) {
+		request := &RequestPermitsBSON{}
+		if err := cur.Decode(request); err != nil {
+			return nil, err
+		}
+
+		requests = append(requests, request.RequestPermits())
+	}
+
+	if err := cur.Err(); err != nil {
+		return nil, err
+	}
+
+	return requests, nil
+}
+
+// UpdateStatus updates all permits that match filter to the status of change,
+// and appends change to their history.
+// Returns the number of updated permits and a nil error,
+// otherwise returns 0 and non-nil error if any occurred.
+func (s MongoStore) UpdateStatus(ctx context.Context, filter interface{}, change service.StatusChange) (int64, error) {
 	collection := s.DB.Collection(PermitCollectionName)
-	if reqID == "" {
-		return fmt.Errorf("reqID is required")
+	if change.Status == "" {
+		return 0, fmt.Errorf("status is required")
+	}
+
+	result, err := collection.UpdateMany(ctx, filter, statusUpdate(change))
+	if err != nil {
+		return 0, fmt.Errorf("error while updating status %v", err)
+	}
+
+	return result.ModifiedCount, nil
+}
+
+// statusUpdate returns the update of changing the status of a permit by change.
+func statusUpdate(change service.StatusChange) bson.D {
+	set := bson.M{
+		PermitBSONStatusField:    change.Status,
+		PermitBSONUpdatedAtField: change.Time,
+	}
+
+	if service.IsDecision(change.Status) {
+		set[PermitBSONDecidedAtField] = change.Time
+	}
+
+	return bson.D{
+		bson.E{
+			Key:   "$set",
+			Value: set,
+		},
+		bson.E{
+			Key:   "$push",
+			Value: bson.M{PermitBSONHistoryField: newStatusChangeBSON(change)},
+		},
+	}
+}
+
+// WithTransaction runs fn in a transaction, fn must use the context it is called with
+// for all of the operations that should be part of the transaction.
+// fn may be run multiple times if the transaction is retried, so it must be idempotent.
+func (s MongoStore) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	session, err := s.DB.Client().StartSession()
+	if err != nil {
+		return fmt.Errorf("failed starting session %v", err)
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sessCtx mongo.SessionContext) (interface{}, error) {
+		return nil, fn(sessCtx)
+	})
+
+	return err
+}
+
+// CreateOutboxMessage stores message in the outbox,
+// if successful returns the ID of the stored message and a nil error,
+// otherwise returns empty string and non-nil error if any occurred.
+func (s MongoStore) CreateOutboxMessage(ctx context.Context, message service.OutboxMessage) (string, error) {
+	collection := s.DB.Collection(OutboxCollectionName)
+
+	result, err := collection.InsertOne(ctx, newOutboxBSON(message))
+	if err != nil {
+		return "", err
 	}
 
+	id, ok := result.InsertedID.(primitive.ObjectID)
+	if !ok {
+		return "", fmt.Errorf("unexpected outbox message ID %v", result.InsertedID)
+	}
+
+	return id.Hex(), nil
+}
+
+// ClaimOutboxMessage finds the outbox message that is due the earliest at now, and
+// postpones its next attempt by lease so no one else would claim it while it's delivered,
+// if the outbox has no due messages it would return mongo.ErrNoDocuments error,
+// otherwise returns the claimed message with its attempts incremented.
+func (s MongoStore) ClaimOutboxMessage(ctx context.Context, now time.Time, lease time.Duration) (service.OutboxMessage, error) {
+	collection := s.DB.Collection(OutboxCollectionName)
+
 	filter := bson.D{
 		bson.E{
-			Key:   PermitBSONReqIDField,
-			Value: reqID,
+			Key:   OutboxBSONNextAttemptAtField,
+			Value: bson.M{"$lte": now},
 		},
 	}
 
 	update := bson.M{
 		"$set": bson.M{
-			PermitBSONStatusField: status,
+			OutboxBSONNextAttemptAtField: now.Add(lease),
+		},
+		"$inc": bson.M{
+			OutboxBSONAttemptsField: 1,
 		},
 	}
 
-	// Call the driver's UpdateMany() method and pass filter and update to it
-	result, err := collection.UpdateMany(
-		context.Background(),
-		filter,
-		update,
-	)
-	fmt.Println(result)
+	opts := options.FindOneAndUpdate().
+		SetSort(bson.D{bson.E{Key: OutboxBSONNextAttemptAtField, Value: 1}}).
+		SetReturnDocument(options.After)
+
+	message := OutboxBSON{}
+	if err := collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&message); err != nil {
+		return service.OutboxMessage{}, err
+	}
+
+	return message.OutboxMessage(), nil
+}
 
-	// opts := options.Update()
-	optsF := options.Find()
-	many, err := collection.Find(ctx, filter, optsF)
-	many.Decode(&BSON{})
+// RetryOutboxMessage schedules the next attempt of the outbox message id to nextAttemptAt
+// and records lastError as the reason its previous attempt failed.
+func (s MongoStore) RetryOutboxMessage(ctx context.Context, id string, nextAttemptAt time.Time, lastError string) error {
+	collection := s.DB.Collection(OutboxCollectionName)
 
-	// _, err = collection.UpdateMany(ctx, filter, update, opts)
+	objectID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
-		return fmt.Errorf("error while updating status %v", err)
+		return err
 	}
 
-	return nil
+	update := bson.M{
+		"$set": bson.M{
+			OutboxBSONNextAttemptAtField: nextAttemptAt,
+			OutboxBSONLastErrorField:     lastError,
+		},
+	}
+
+	_, err = collection.UpdateOne(ctx, bson.M{MongoObjectIDField: objectID}, update)
+	return err
+}
+
+// GetOutboxMessages finds all of the outbox messages that match filter,
+// if successful returns the messages, and a nil error,
+// otherwise returns nil and non-nil error if any occurred.
+func (s MongoStore) GetOutboxMessages(ctx context.Context, filter interface{}) ([]service.OutboxMessage, error) {
+	collection := s.DB.Collection(OutboxCollectionName)
+
+	cur, err := collection.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	messages := []service.OutboxMessage{}
+	for cur.Next(ctx) {
+		message := OutboxBSON{}
+		if err := cur.Decode(&message); err != nil {
+			return nil, err
+		}
+
+		messages = append(messages, message.OutboxMessage())
+	}
+
+	if err := cur.Err(); err != nil {
+		return nil, err
+	}
+
+	return messages, nil
+}
+
+// DeleteOutboxMessages removes all of the outbox messages that match filter,
+// returns the number of removed messages.
+func (s MongoStore) DeleteOutboxMessages(ctx context.Context, filter interface{}) (int64, error) {
+	collection := s.DB.Collection(OutboxCollectionName)
+
+	result, err := collection.DeleteMany(ctx, filter)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.DeletedCount, nil
+}
+
+// DeleteOutboxMessage removes the outbox message id from the outbox.
+func (s MongoStore) DeleteOutboxMessage(ctx context.Context, id string) error {
+	collection := s.DB.Collection(OutboxCollectionName)
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	_, err = collection.DeleteOne(ctx, bson.M{MongoObjectIDField: objectID})
+	return err
 }
@@ -0,0 +1,195 @@
+package mongodb
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/meateam/permit-service/service"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	// changeStreamsUnsupportedCode is the error code of opening a change stream
+	// on a standalone mongodb server.
+	changeStreamsUnsupportedCode = 40573
+
+	// watchPollInterval is the interval of polling for changed permits
+	// when change streams are unsupported.
+	watchPollInterval = 2 * time.Second
+
+	// watchPollOverlap is how long before each poll the permits are polled from. Permits are stamped
+	// with their updatedAt before their update commits, so an update may become visible after later
+	// stamped ones, the overlap must be longer than the longest time an update takes to commit.
+	watchPollOverlap = time.Minute
+
+	// changeEventFullDocumentField is the name of the field holding the changed document in a change event.
+	changeEventFullDocumentField = "fullDocument"
+)
+
+// changeEvent is the struct that represents a change stream event of the permits collection.
+type changeEvent struct {
+	OperationType string `bson:"operationType"`
+	FullDocument  *BSON  `bson:"fullDocument"`
+}
+
+// WatchPermits calls send with each permit that matches filter whenever it's created or updated,
+// until ctx is done or send returns an error. Changes are watched with a change stream, falling back
+// to polling the permits collection if the mongodb server doesn't support change streams. Note that
+// only watching works without a replica set, the service's writes run in transactions which need one.
+func (c Controller) WatchPermits(ctx context.Context, filter service.WatchFilter, send func(service.Permit) error) error {
+	err := c.watchChangeStream(ctx, filter, send)
+	if cmdErr, ok := err.(mongo.CommandError); ok && cmdErr.Code == changeStreamsUnsupportedCode {
+		return c.pollChanges(ctx, filter, send)
+	}
+
+	return err
+}
+
+// watchChangeStream calls send with each permit that matches filter from the change stream of the
+// permits collection, until ctx is done or send returns an error.
+func (c Controller) watchChangeStream(
+	ctx context.Context,
+	filter service.WatchFilter,
+	send func(service.Permit) error,
+) error {
+	match := bson.D{
+		bson.E{
+			Key:   "operationType",
+			Value: bson.M{"$in": bson.A{"insert", "update", "replace"}},
+		},
+	}
+
+	for _, e := range watchFilter(filter) {
+		match = append(match, bson.E{Key: changeEventFullDocumentField + "." + e.Key, Value: e.Value})
+	}
+
+	pipeline := mongo.Pipeline{bson.D{bson.E{Key: "$match", Value: match}}}
+	stream, err := c.store.Watch(ctx, pipeline)
+	if err != nil {
+		return err
+	}
+	defer stream.Close(context.Background())
+
+	for stream.Next(ctx) {
+		event := changeEvent{}
+		if err := stream.Decode(&event); err != nil {
+			return err
+		}
+
+		// The permit may have been deleted by the time its update was looked up.
+		if event.FullDocument == nil {
+			continue
+		}
+
+		if err := send(event.FullDocument); err != nil {
+			return err
+		}
+	}
+
+	if ctx.Err() != nil {
+		return nil
+	}
+
+	return toStatusError(stream.Err(), "failed watching permits")
+}
+
+// pollChanges calls send with each permit that matches filter whenever it's updated, polling once in
+// watchPollInterval until ctx is done or send returns an error. Each poll matches the permits updated
+// in the last watchPollOverlap, the updates that were already sent are skipped.
+func (c Controller) pollChanges(ctx context.Context, filter service.WatchFilter, send func(service.Permit) error) error {
+	ticker := time.NewTicker(watchPollInterval)
+	defer ticker.Stop()
+
+	start := time.Now()
+
+	// sent holds the update times of the permits that were sent by their IDs, for the updates
+	// that may still be matched by the next poll.
+	sent := map[string][]time.Time{}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		since := time.Now().Add(-watchPollOverlap)
+		if since.Before(start) {
+			since = start
+		}
+
+		pruneSent(sent, since)
+		pollFilter := append(watchFilter(filter), bson.E{
+			Key:   PermitBSONUpdatedAtField,
+			Value: bson.M{"$gte": since},
+		})
+
+		permits, err := c.store.GetAll(ctx, pollFilter)
+		if err != nil {
+			return toStatusError(err, "failed polling permits")
+		}
+
+		sort.Slice(permits, func(i, j int) bool {
+			return permits[i].GetUpdatedAt().Before(permits[j].GetUpdatedAt())
+		})
+
+		for _, permit := range permits {
+			if wasSent(sent, permit) {
+				continue
+			}
+
+			sent[permit.GetID()] = append(sent[permit.GetID()], permit.GetUpdatedAt())
+			if err := send(permit); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// wasSent returns true if the update of permit is one of the updates in sent.
+func wasSent(sent map[string][]time.Time, permit service.Permit) bool {
+	for _, updatedAt := range sent[permit.GetID()] {
+		if updatedAt.Equal(permit.GetUpdatedAt()) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// pruneSent removes the updates from sent that are older than since, which polls no longer match.
+func pruneSent(sent map[string][]time.Time, since time.Time) {
+	for id, updates := range sent {
+		kept := updates[:0]
+		for _, updatedAt := range updates {
+			if !updatedAt.Before(since) {
+				kept = append(kept, updatedAt)
+			}
+		}
+
+		if len(kept) == 0 {
+			delete(sent, id)
+		} else {
+			sent[id] = kept
+		}
+	}
+}
+
+// watchFilter returns the filter of the permits that match filter.
+func watchFilter(filter service.WatchFilter) bson.D {
+	fields := []bson.E{
+		{Key: PermitBSONFileIDField, Value: filter.FileID},
+		{Key: PermitBSONUserIDField, Value: filter.UserID},
+		{Key: PermitBSONReqIDField, Value: filter.ReqID},
+	}
+
+	permitFilter := bson.D{}
+	for _, field := range fields {
+		if field.Value != "" {
+			permitFilter = append(permitFilter, field)
+		}
+	}
+
+	return permitFilter
+}
@@ -0,0 +1,41 @@
+package service
+
+import (
+	"time"
+)
+
+const (
+	// OutboxKindCreate is the kind of a message filing a new approval request.
+	OutboxKindCreate = "create"
+
+	// OutboxKindCancel is the kind of a message cancelling a filed approval request.
+	OutboxKindCancel = "cancel"
+)
+
+// OutboxMessage is a message to the approval service that is stored in the same transaction as
+// the permits it was created for, and is delivered to the approval service by the Dispatcher.
+// A message of OutboxKindCreate files Request, and a message of OutboxKindCancel cancels ReqID.
+type OutboxMessage struct {
+	ID            string
+	Kind          string
+	ReqID         string
+	Request       ApprovalReqType
+	Attempts      int
+	NextAttemptAt time.Time
+	LastError     string
+	CreatedAt     time.Time
+}
+
+// CancelAfter returns the cancel message scheduled no earlier than the next attempt of each of the
+// undelivered messages filing the approval request it cancels. A claimed message's next attempt is
+// when its lease ends, so the cancellation reaches the approval service only after an attempt that
+// may still be in flight, and may still file the approval request, is over.
+func CancelAfter(cancel OutboxMessage, undelivered []OutboxMessage) OutboxMessage {
+	for _, message := range undelivered {
+		if message.NextAttemptAt.After(cancel.NextAttemptAt) {
+			cancel.NextAttemptAt = message.NextAttemptAt
+		}
+	}
+
+	return cancel
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidPageToken is returned from the listing methods of a Controller when the page token
+// isn't a token the Controller returned.
+var ErrInvalidPageToken = errors.New("invalid pageToken")
+
+const (
+	// DefaultPageSize is the size of a page if the request doesn't specify one.
+	DefaultPageSize = 50
+
+	// MaxPageSize is the largest size of a page a request may specify.
+	MaxPageSize = 500
+)
+
+// pageSize returns the page size to list with for the requested page size.
+// Returns a codes.InvalidArgument status error if requested is negative.
+func pageSize(requested int32) (int64, error) {
+	switch {
+	case requested < 0:
+		return 0, invalidArgument("pageSize", "pageSize must not be negative")
+	case requested == 0:
+		return DefaultPageSize, nil
+	case requested > MaxPageSize:
+		return MaxPageSize, nil
+	default:
+		return int64(requested), nil
+	}
+}
+
+// parseStatuses returns the statuses that each of names names.
+// Returns a codes.InvalidArgument status error if any of names is not a valid status.
+func parseStatuses(names []string) ([]string, error) {
+	statuses := make([]string, 0, len(names))
+	for i, name := range names {
+		permitStatus, err := ParseStatus(name)
+		if err != nil {
+			return nil, invalidArgument(fmt.Sprintf("statuses[%d]", i), err.Error())
+		}
+
+		statuses = append(statuses, permitStatus)
+	}
+
+	return statuses, nil
+}
+
+// pageTokenError returns the error of listing a page which failed with err, an ErrInvalidPageToken
+// is returned as a codes.InvalidArgument status error of the pageToken field.
+func pageTokenError(err error) error {
+	if err == ErrInvalidPageToken {
+		return invalidArgument("pageToken", "invalid pageToken")
+	}
+
+	return err
+}
+
+// NextPageToken returns the page token of the page after the page whose last object is lastID,
+// or an empty string if there are no more pages.
+func NextPageToken(lastID string, hasMore bool) string {
+	if !hasMore {
+		return ""
+	}
+
+	return lastID
+}
@@ -1,6 +1,8 @@
 package service
 
 import (
+	"time"
+
 	pb "github.com/meateam/permit-service/proto"
 )
 
@@ -21,5 +23,99 @@ type Permit interface {
 	GetStatus() string
 	SetStatus(status string) error
 
+	GetExpiresAt() time.Time
+	SetExpiresAt(expiresAt time.Time) error
+
+	GetCreatedAt() time.Time
+	SetCreatedAt(createdAt time.Time) error
+
+	GetUpdatedAt() time.Time
+	SetUpdatedAt(updatedAt time.Time) error
+
+	GetDecidedAt() time.Time
+	SetDecidedAt(decidedAt time.Time) error
+
+	GetHistory() []StatusChange
+	AppendHistory(change StatusChange) error
+
 	MarshalProto(permit *pb.PermitObject) error
 }
+
+// MaxBatchSize is the largest number of permits that may be checked at once.
+const MaxBatchSize = 1000
+
+// PermitKey identifies the permit of a user to a file.
+type PermitKey struct {
+	FileID string
+	UserID string
+}
+
+// WatchFilter filters watched permits by any of their FileID, UserID and ReqID,
+// empty fields are not filtered by.
+type WatchFilter struct {
+	FileID string
+	UserID string
+	ReqID  string
+}
+
+// StatusChange is an entry of the append-only status history of a permit,
+// recording who changed the permit's status, when and why.
+type StatusChange struct {
+	Status string
+	Actor  string
+	Reason string
+	Time   time.Time
+}
+
+// MarshalProto marshals c into a status change.
+func (c StatusChange) MarshalProto(change *pb.StatusChange) error {
+	change.Status = c.Status
+	change.Actor = c.Actor
+	change.Reason = c.Reason
+	change.Time = UnixMillis(c.Time)
+
+	return nil
+}
+
+// IsDecision returns true if a permit changing to status is decided by its approvers.
+func IsDecision(status string) bool {
+	return status == StatusApproved || status == StatusDenied
+}
+
+// NewUserStatus returns the status of the user of permit along with its timestamps and history.
+func NewUserStatus(permit Permit) *pb.UserStatus {
+	userStatus := &pb.UserStatus{
+		UserId:    permit.GetUserID(),
+		Status:    permit.GetStatus(),
+		CreatedAt: UnixMillis(permit.GetCreatedAt()),
+		UpdatedAt: UnixMillis(permit.GetUpdatedAt()),
+		DecidedAt: UnixMillis(permit.GetDecidedAt()),
+		ExpiresAt: UnixMillis(permit.GetExpiresAt()),
+	}
+
+	for _, change := range permit.GetHistory() {
+		changeProto := &pb.StatusChange{}
+		_ = change.MarshalProto(changeProto)
+		userStatus.History = append(userStatus.History, changeProto)
+	}
+
+	return userStatus
+}
+
+// FromUnixMillis returns the time of ms milliseconds since the unix epoch, or the zero time if ms is 0.
+func FromUnixMillis(ms int64) time.Time {
+	if ms == 0 {
+		return time.Time{}
+	}
+
+	return time.Unix(0, ms*int64(time.Millisecond))
+}
+
+// UnixMillis returns t as milliseconds since the unix epoch, or 0 if t is zero.
+func UnixMillis(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+
+	return t.UnixNano() / int64(time.Millisecond)
+}
@@ -0,0 +1,258 @@
+package rabbitmq
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/meateam/permit-service/service"
+	"github.com/sirupsen/logrus"
+	"github.com/streadway/amqp"
+)
+
+const (
+	// exchangeKind is the kind of the exchange events are published to,
+	// events are routed by their type.
+	exchangeKind = "topic"
+
+	// contentType is the content type of the published events.
+	contentType = "application/json"
+
+	// queueSize is the number of batches of events that may wait to be published,
+	// events published while the queue is full are dropped.
+	queueSize = 1024
+
+	// connectTimeout is the timeout of connecting to the server, including the handshake.
+	connectTimeout = 5 * time.Second
+
+	// heartbeat is the interval of the heartbeats of the connection to the server.
+	heartbeat = 10 * time.Second
+
+	// minRetryDelay is the delay before the first retry of publishing a batch of events,
+	// the delay is doubled after every failed attempt up to maxRetryDelay.
+	minRetryDelay = time.Second
+
+	// maxRetryDelay is the maximum delay between attempts of publishing a batch of events.
+	maxRetryDelay = 30 * time.Second
+)
+
+// Publisher is a service.EventPublisher that publishes permit events to a RabbitMQ exchange,
+// routed by their type. Events are queued and published in the background, so publishing never
+// blocks on the server, and a batch of events that failed to publish is retried until it's
+// published or the Publisher is closed, so an event may be published more than once.
+type Publisher struct {
+	exchange string
+	logger   *logrus.Logger
+	dial     func() (channel, error)
+
+	queue     chan []service.PermitEvent
+	done      chan struct{}
+	stopped   chan struct{}
+	closeOnce sync.Once
+
+	// channel is only used by the goroutine publishing the queued events.
+	channel channel
+}
+
+// channel is a channel to the server on which events are published.
+type channel interface {
+	Publish(exchange string, key string, mandatory bool, immediate bool, msg amqp.Publishing) error
+	Close() error
+}
+
+// connChannel is a channel that owns its connection, closing it closes the connection.
+type connChannel struct {
+	*amqp.Channel
+	conn *amqp.Connection
+}
+
+// Close closes the connection of c.
+func (c connChannel) Close() error {
+	return c.conn.Close()
+}
+
+// NewPublisher connects to the RabbitMQ server at url and declares the topic exchange
+// the events are published to, returns the created Publisher which logs with logger
+// the events it failed to publish.
+func NewPublisher(url string, exchange string, logger *logrus.Logger) (*Publisher, error) {
+	return newPublisher(exchange, logger, func() (channel, error) {
+		return dial(url, exchange)
+	})
+}
+
+// newPublisher returns a Publisher that publishes events to exchange on the channels opened by dial.
+func newPublisher(exchange string, logger *logrus.Logger, dial func() (channel, error)) (*Publisher, error) {
+	p := &Publisher{
+		exchange: exchange,
+		logger:   logger,
+		dial:     dial,
+		queue:    make(chan []service.PermitEvent, queueSize),
+		done:     make(chan struct{}),
+		stopped:  make(chan struct{}),
+	}
+
+	if err := p.connect(); err != nil {
+		return nil, err
+	}
+
+	go p.run()
+
+	return p, nil
+}
+
+// Publish queues events to be published as persistent json messages routed by their type.
+// Returns a non-nil error if the events were dropped since the queue is full or p is closed.
+func (p *Publisher) Publish(ctx context.Context, events ...service.PermitEvent) error {
+	select {
+	case <-p.done:
+		return fmt.Errorf("publisher is closed, dropped %d events", len(events))
+	default:
+	}
+
+	select {
+	case p.queue <- events:
+		return nil
+	default:
+		return fmt.Errorf("event queue is full, dropped %d events", len(events))
+	}
+}
+
+// Close stops accepting events and closes the connection to the server once the queued events
+// are published. The queued events are attempted once more, those that fail are dropped.
+func (p *Publisher) Close() error {
+	p.closeOnce.Do(func() {
+		close(p.done)
+	})
+
+	<-p.stopped
+
+	if p.channel == nil {
+		return nil
+	}
+
+	return p.channel.Close()
+}
+
+// run publishes the queued events until p is closed, then publishes the events left in the queue.
+func (p *Publisher) run() {
+	defer close(p.stopped)
+
+	for {
+		select {
+		case <-p.done:
+			p.drain()
+			return
+		case events := <-p.queue:
+			p.publishWithRetry(events)
+		}
+	}
+}
+
+// drain attempts publishing each batch of events left in the queue once.
+func (p *Publisher) drain() {
+	for {
+		select {
+		case events := <-p.queue:
+			if err := p.publish(events); err != nil {
+				p.logger.Errorf("publisher is closed, dropped %d permit events: %v", len(events), err)
+			}
+		default:
+			return
+		}
+	}
+}
+
+// publishWithRetry publishes events, retrying with a backoff until they are published or p is closed.
+func (p *Publisher) publishWithRetry(events []service.PermitEvent) {
+	delay := minRetryDelay
+	for {
+		err := p.publish(events)
+		if err == nil {
+			return
+		}
+
+		p.logger.Errorf("failed publishing %d permit events, retrying in %v: %v", len(events), delay, err)
+
+		select {
+		case <-p.done:
+			p.logger.Errorf("publisher is closed, dropped %d permit events", len(events))
+			return
+		case <-time.After(delay):
+		}
+
+		delay *= 2
+		if delay > maxRetryDelay {
+			delay = maxRetryDelay
+		}
+	}
+}
+
+// publish publishes each of events, reconnecting to the server if the connection was lost.
+func (p *Publisher) publish(events []service.PermitEvent) error {
+	if p.channel == nil {
+		if err := p.connect(); err != nil {
+			return err
+		}
+	}
+
+	for _, event := range events {
+		body, err := json.Marshal(event)
+		if err != nil {
+			return fmt.Errorf("failed marshaling event %s: %v", event.Type, err)
+		}
+
+		msg := amqp.Publishing{
+			ContentType:  contentType,
+			DeliveryMode: amqp.Persistent,
+			Timestamp:    event.Time,
+			Body:         body,
+		}
+
+		if err := p.channel.Publish(p.exchange, event.Type, false, false, msg); err != nil {
+			// The channel is unusable after a failed publish, it's reopened by the next attempt.
+			p.channel.Close()
+			p.channel = nil
+			return fmt.Errorf("failed publishing event %s: %v", event.Type, err)
+		}
+	}
+
+	return nil
+}
+
+// connect opens the channel events are published on.
+func (p *Publisher) connect() error {
+	channel, err := p.dial()
+	if err != nil {
+		return err
+	}
+
+	p.channel = channel
+	return nil
+}
+
+// dial opens a connection and a channel to the RabbitMQ server at url and declares exchange.
+func dial(url string, exchange string) (channel, error) {
+	conn, err := amqp.DialConfig(url, amqp.Config{
+		Heartbeat: heartbeat,
+		Locale:    "en_US",
+		Dial:      amqp.DefaultDial(connectTimeout),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed connecting to rabbitmq: %v", err)
+	}
+
+	channel, err := conn.Channel()
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed opening rabbitmq channel: %v", err)
+	}
+
+	if err := channel.ExchangeDeclare(exchange, exchangeKind, true, false, false, false, nil); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed declaring exchange %s: %v", exchange, err)
+	}
+
+	return connChannel{Channel: channel, conn: conn}, nil
+}
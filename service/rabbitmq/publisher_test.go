@@ -0,0 +1,169 @@
+package rabbitmq
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/meateam/permit-service/service"
+	"github.com/sirupsen/logrus"
+	"github.com/streadway/amqp"
+)
+
+const testExchange = "permits"
+
+// published is a message published on a fakeChannel.
+type published struct {
+	exchange string
+	key      string
+	msg      amqp.Publishing
+}
+
+// fakeChannel is an in-process channel that sends the messages published on it to messages,
+// or fails publishing them with err.
+type fakeChannel struct {
+	messages chan<- published
+	err      error
+	closed   bool
+}
+
+func (c *fakeChannel) Publish(exchange string, key string, mandatory bool, immediate bool, msg amqp.Publishing) error {
+	if c.err != nil {
+		return c.err
+	}
+
+	c.messages <- published{exchange: exchange, key: key, msg: msg}
+	return nil
+}
+
+func (c *fakeChannel) Close() error {
+	c.closed = true
+	return nil
+}
+
+// newTestPublisher returns a Publisher whose dials open each of channels in turn, failing t if it fails.
+func newTestPublisher(t *testing.T, channels ...*fakeChannel) *Publisher {
+	t.Helper()
+
+	logger := logrus.New()
+	logger.SetOutput(ioutil.Discard)
+
+	p, err := newPublisher(testExchange, logger, func() (channel, error) {
+		if len(channels) == 0 {
+			return nil, errors.New("no more channels")
+		}
+
+		c := channels[0]
+		channels = channels[1:]
+		return c, nil
+	})
+	if err != nil {
+		t.Fatalf("newPublisher() failed: %v", err)
+	}
+
+	return p
+}
+
+// receive returns the next message sent to messages, failing t if none is sent in time.
+func receive(t *testing.T, messages <-chan published) published {
+	t.Helper()
+
+	select {
+	case message := <-messages:
+		return message
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for a published message")
+		return published{}
+	}
+}
+
+func TestPublisherPublish(t *testing.T) {
+	messages := make(chan published, 2)
+	c := &fakeChannel{messages: messages}
+	p := newTestPublisher(t, c)
+
+	events := []service.PermitEvent{
+		{Type: service.EventPermitCreated, ReqID: "req", UserID: "a", Status: service.StatusPending},
+		{Type: service.EventType(service.StatusApproved), ReqID: "req", UserID: "b", Status: service.StatusApproved},
+	}
+
+	if err := p.Publish(context.Background(), events...); err != nil {
+		t.Fatalf("Publish() failed: %v", err)
+	}
+
+	for _, want := range events {
+		message := receive(t, messages)
+		if message.exchange != testExchange || message.key != want.Type {
+			t.Errorf("published to %s with key %s, want %s with key %s", message.exchange, message.key, testExchange, want.Type)
+		}
+
+		if message.msg.DeliveryMode != amqp.Persistent || message.msg.ContentType != contentType {
+			t.Errorf("published %s message with delivery mode %d, want persistent %s", message.msg.ContentType, message.msg.DeliveryMode, contentType)
+		}
+
+		got := service.PermitEvent{}
+		if err := json.Unmarshal(message.msg.Body, &got); err != nil {
+			t.Fatalf("failed unmarshaling published event: %v", err)
+		}
+
+		if got != want {
+			t.Errorf("published event %+v, want %+v", got, want)
+		}
+	}
+
+	if err := p.Close(); err != nil {
+		t.Fatalf("Close() failed: %v", err)
+	}
+
+	if !c.closed {
+		t.Error("Close() didn't close the channel")
+	}
+
+	if err := p.Publish(context.Background(), events...); err == nil {
+		t.Error("Publish() after Close() succeeded, want an error")
+	}
+}
+
+func TestPublisherRetriesOnNewChannel(t *testing.T) {
+	messages := make(chan published, 1)
+	broken := &fakeChannel{err: errors.New("channel closed")}
+	c := &fakeChannel{messages: messages}
+	p := newTestPublisher(t, broken, c)
+	defer p.Close()
+
+	event := service.PermitEvent{Type: service.EventType(service.StatusRevoked), ReqID: "req", UserID: "a"}
+	if err := p.Publish(context.Background(), event); err != nil {
+		t.Fatalf("Publish() failed: %v", err)
+	}
+
+	if message := receive(t, messages); message.key != event.Type {
+		t.Errorf("published with key %s, want %s", message.key, event.Type)
+	}
+
+	if !broken.closed {
+		t.Error("the channel that failed publishing wasn't closed")
+	}
+}
+
+func TestPublisherCloseDrainsQueue(t *testing.T) {
+	messages := make(chan published, 3)
+	p := newTestPublisher(t, &fakeChannel{messages: messages})
+
+	for _, userID := range []string{"a", "b", "c"} {
+		event := service.PermitEvent{Type: service.EventPermitCreated, ReqID: "req", UserID: userID}
+		if err := p.Publish(context.Background(), event); err != nil {
+			t.Fatalf("Publish() failed: %v", err)
+		}
+	}
+
+	if err := p.Close(); err != nil {
+		t.Fatalf("Close() failed: %v", err)
+	}
+
+	if len(messages) != 3 {
+		t.Errorf("Close() left %d of 3 queued events unpublished", 3-len(messages))
+	}
+}
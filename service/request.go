@@ -0,0 +1,70 @@
+package service
+
+import (
+	"time"
+
+	pb "github.com/meateam/permit-service/proto"
+)
+
+const (
+	// SubmissionQueued is the submission of an approval request that wasn't accepted by the approval service yet.
+	SubmissionQueued = "queued"
+
+	// SubmissionSubmitted is the submission of an approval request the approval service accepted.
+	SubmissionSubmitted = "submitted"
+
+	// SubmissionRejected is the submission of an approval request the approval service rejected.
+	SubmissionRejected = "rejected"
+)
+
+// UserResult is the result of creating the permit of a user of a request, as it's stored with the request.
+// Result is the name of its pb.UserResultStatus and Status is the status the user's permit was created with.
+type UserResult struct {
+	UserID string
+	Result string
+	Status string
+	Reason string
+}
+
+// PermitRequest is an interface of a request to permit users to access a file,
+// holding the metadata the request was created with.
+type PermitRequest interface {
+	GetID() string
+	GetReqID() string
+	GetFileID() string
+	GetFileName() string
+	GetSharerID() string
+	GetApprovers() []string
+	GetClassification() string
+	GetInfo() string
+	GetUsers() []UserType
+	GetCreatedAt() time.Time
+	GetExpiresAt() time.Time
+	GetApprovalID() string
+	GetIdempotencyKey() string
+	GetResults() []UserResult
+	GetSubmission() string
+	GetSubmissionMessage() string
+
+	MarshalProto(request *pb.PermitRequestObject) error
+}
+
+// RequestPermits is a permit request along with the permits it created.
+type RequestPermits struct {
+	Request PermitRequest
+	Permits []Permit
+}
+
+// MarshalProto marshals r into a permit request with the status of each of its users.
+func (r RequestPermits) MarshalProto(request *pb.PermitRequestObject) error {
+	if err := r.Request.MarshalProto(request); err != nil {
+		return err
+	}
+
+	request.UserStatus = make([]*pb.UserStatus, 0, len(r.Permits))
+	for _, permit := range r.Permits {
+		request.UserStatus = append(request.UserStatus, NewUserStatus(permit))
+	}
+
+	return nil
+}
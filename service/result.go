@@ -0,0 +1,101 @@
+package service
+
+import (
+	pb "github.com/meateam/permit-service/proto"
+	"google.golang.org/grpc/status"
+)
+
+// newUserResult returns the result of creating the permit of userID whose status is permitStatus.
+func newUserResult(userID string, result pb.UserResultStatus, permitStatus string, reason string) *pb.UserResult {
+	return &pb.UserResult{
+		UserID: userID,
+		Result: result,
+		Status: permitStatus,
+		Reason: reason,
+	}
+}
+
+// newApprovalSubmission returns the outcome of submitting an approval request that the approval service
+// gave approvalID, or failed with err.
+func newApprovalSubmission(approvalID string, err error) *pb.ApprovalSubmission {
+	switch {
+	case err == nil:
+		return &pb.ApprovalSubmission{Status: pb.SubmissionStatus_SUBMISSION_SUBMITTED, ApprovalID: approvalID}
+	case IsPermanentError(err):
+		return &pb.ApprovalSubmission{Status: pb.SubmissionStatus_SUBMISSION_REJECTED, Message: status.Convert(err).Message()}
+	default:
+		return &pb.ApprovalSubmission{Status: pb.SubmissionStatus_SUBMISSION_QUEUED, Message: status.Convert(err).Message()}
+	}
+}
+
+// newStoredResults returns the results to store with a request whose users' results are results.
+func newStoredResults(results []*pb.UserResult) []UserResult {
+	stored := make([]UserResult, 0, len(results))
+	for _, result := range results {
+		stored = append(stored, UserResult{
+			UserID: result.GetUserID(),
+			Result: result.GetResult().String(),
+			Status: result.GetStatus(),
+			Reason: result.GetReason(),
+		})
+	}
+
+	return stored
+}
+
+// failCreatedResults updates the results of the users whose permits were created to failing to submit
+// them for approval, after the approval service rejected their approval request with message.
+func failCreatedResults(results []*pb.UserResult, message string) {
+	for _, result := range results {
+		if result.GetResult() == pb.UserResultStatus_USER_RESULT_CREATED {
+			result.Result = pb.UserResultStatus_USER_RESULT_FAILED
+			result.Status = StatusFailedToSubmit
+			result.Reason = message
+		}
+	}
+}
+
+// storedPermitResults returns the results of the users of request, in the order of its users, and the
+// outcome of submitting its approval request, just like they were returned when request was created.
+func storedPermitResults(request PermitRequest) ([]*pb.UserResult, *pb.ApprovalSubmission) {
+	results := make([]*pb.UserResult, 0, len(request.GetResults()))
+	for _, result := range request.GetResults() {
+		results = append(results, newUserResult(
+			result.UserID,
+			pb.UserResultStatus(pb.UserResultStatus_value[result.Result]),
+			result.Status,
+			result.Reason,
+		))
+	}
+
+	var approval *pb.ApprovalSubmission
+	switch request.GetSubmission() {
+	case SubmissionSubmitted:
+		approval = &pb.ApprovalSubmission{
+			Status:     pb.SubmissionStatus_SUBMISSION_SUBMITTED,
+			ApprovalID: request.GetApprovalID(),
+		}
+	case SubmissionRejected:
+		approval = &pb.ApprovalSubmission{
+			Status:  pb.SubmissionStatus_SUBMISSION_REJECTED,
+			Message: request.GetSubmissionMessage(),
+		}
+
+		failCreatedResults(results, request.GetSubmissionMessage())
+	default:
+		approval = &pb.ApprovalSubmission{Status: pb.SubmissionStatus_SUBMISSION_QUEUED}
+	}
+
+	return results, approval
+}
+
+// withResponse returns the status error err detailed with res,
+// so that clients can tell what was done for the request that failed.
+func withResponse(err error, res *pb.CreatePermitResponse) error {
+	detailed, detailErr := status.Convert(err).WithDetails(res)
+	if detailErr != nil {
+		return err
+	}
+
+	return detailed.Err()
+}
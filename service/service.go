@@ -1,35 +1,25 @@
 package service
 
 import (
-	"bytes"
 	"context"
-	"crypto/tls"
-	"encoding/json"
 	"fmt"
-	"net/http"
-	"sync"
 	"time"
 
 	pb "github.com/meateam/permit-service/proto"
-	spb "github.com/meateam/spike-service/proto/spike-service"
 	"github.com/segmentio/ksuid"
 	"github.com/sirupsen/logrus"
-	"google.golang.org/grpc"
-)
-
-const (
-	// StatusPending is the status of a pending request
-	StatusPending = "pending"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // Service is the structure used for handling
 type Service struct {
-	spikeClient spb.SpikeClient
-	controller  Controller
-	logger      *logrus.Logger
-	grantType   string
-	audience    string
-	approvalURL string
+	controller Controller
+	logger     *logrus.Logger
+	approvals  ApprovalClient
+	dispatcher *Dispatcher
+	publisher  EventPublisher
 }
 
 // ApprovalReqType is the struct sent as json to the approval service
@@ -42,6 +32,14 @@ type ApprovalReqType struct {
 	FileName       string     `json:"fileName"`
 	Info           string     `json:"info"`
 	Classification string     `json:"classification"`
+	ExpiresAt      int64      `json:"expiresAt,omitempty"`
+
+	// IdempotencyKey is stored with the permit request and isn't sent to the approval service.
+	IdempotencyKey string `json:"-"`
+
+	// Results are the results of the request's users, which are stored with the permit request
+	// so a retry of the request is responded with them, and aren't sent to the approval service.
+	Results []UserResult `json:"-" bson:"-"`
 }
 
 // UserType is the struct that contains id and fullname of a user
@@ -64,12 +62,34 @@ func (s *Service) HealthCheck(mongoClientPingTimeout time.Duration) bool {
 }
 
 // NewService creates a Service and returns it.
-func NewService(controller Controller, logger *logrus.Logger, spikeConn *grpc.ClientConn, grantType string, audience string, approvalURL string) Service {
-	s := Service{controller: controller, logger: logger, grantType: grantType, audience: audience, approvalURL: approvalURL}
-	s.spikeClient = spb.NewSpikeClient(spikeConn)
+// The approval requests of permits are filed with approvals.
+// The permit events are published with publisher, if publisher is nil they are discarded.
+func NewService(
+	controller Controller,
+	logger *logrus.Logger,
+	approvals ApprovalClient,
+	publisher EventPublisher,
+) Service {
+	if publisher == nil {
+		publisher = NoopPublisher{}
+	}
+
+	s := Service{
+		controller: controller,
+		logger:     logger,
+		approvals:  approvals,
+		publisher:  publisher,
+	}
+	s.dispatcher = newDispatcher(controller, s.deliverApprovalRequest, logger)
 	return s
 }
 
+// DispatchOutbox delivers the approval requests stored in the outbox once in interval,
+// retrying failed deliveries with a backoff of at most maxBackoff. It blocks until ctx is done.
+func (s Service) DispatchOutbox(ctx context.Context, interval time.Duration, maxBackoff time.Duration) {
+	s.dispatcher.Run(ctx, interval, maxBackoff)
+}
+
 // CreatePermit is the request handler for creating a permit of a file to user.
 func (s Service) CreatePermit(ctx context.Context, req *pb.CreatePermitRequest) (*pb.CreatePermitResponse, error) {
 	fileID := req.GetFileID()
@@ -84,29 +104,101 @@ func (s Service) CreatePermit(ctx context.Context, req *pb.CreatePermitRequest)
 
 	usersNum := len(users)
 
+	var violations []*errdetails.BadRequest_FieldViolation
 	if fileID == "" {
-		return nil, fmt.Errorf("fileID is required")
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{
+			Field:       "fileID",
+			Description: "fileID is required",
+		})
 	}
 
 	if sharerID == "" {
-		return nil, fmt.Errorf("sharerID is required")
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{
+			Field:       "sharerID",
+			Description: "sharerID is required",
+		})
 	}
 
 	if usersNum == 0 {
-		return nil, fmt.Errorf("at least one user is required")
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{
+			Field:       "users",
+			Description: "at least one user is required",
+		})
+	}
+
+	for i := 0; i < usersNum; i++ {
+		if users[i].GetId() == "" {
+			violations = append(violations, &errdetails.BadRequest_FieldViolation{
+				Field:       fmt.Sprintf("users[%d].id", i),
+				Description: fmt.Sprintf("users[%d] is missing an id", i),
+			})
+		}
+	}
+
+	if len(violations) > 0 {
+		return nil, badRequest(violations...)
+	}
+
+	// A retried request returns the original request instead of creating the permits again.
+	idempotencyKey := req.GetIdempotencyKey()
+	if idempotencyKey != "" {
+		existing, err := s.existingPermitRequest(ctx, sharerID, idempotencyKey, fileID)
+		if err != nil || existing != nil {
+			return existing, err
+		}
 	}
 
-	reqID, err := ksuid.NewRandomWithTime(time.Now())
+	now := time.Now()
+	expiresAt, err := permitExpiry(now, req.GetExpiresAt(), req.GetTtl())
 	if err != nil {
-		return nil, fmt.Errorf("failed creating reqID")
+		return nil, err
 	}
 
-	// Add the permits to mongo
-	var wg sync.WaitGroup
-	wg.Add(usersNum)
+	reqID, err := ksuid.NewRandomWithTime(now)
+	if err != nil {
+		return nil, internalError(err, "failed creating reqID")
+	}
 
+	// Users who already hold a valid approved permit to the file keep it, instead of having it
+	// replaced by a pending one.
+	keys := make([]PermitKey, 0, usersNum)
+	for _, user := range users {
+		keys = append(keys, PermitKey{FileID: fileID, UserID: user.GetId()})
+	}
+
+	existingPermits, err := s.controller.GetPermitsByKeys(ctx, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	// Each user gets a single permit, even if it appears in users more than once.
 	var userIDs []UserType
+	results := make([]*pb.UserResult, usersNum)
+	seenUsers := make(map[string]bool, usersNum)
 	for i := 0; i < usersNum; i++ {
+		if seenUsers[users[i].GetId()] {
+			results[i] = newUserResult(
+				users[i].GetId(),
+				pb.UserResultStatus_USER_RESULT_SKIPPED,
+				"",
+				"user appears in the request more than once",
+			)
+			continue
+		}
+
+		existing := existingPermits[keys[i]]
+		if Decide(existing, now) == pb.Decision_DECISION_GRANTED {
+			seenUsers[users[i].GetId()] = true
+			results[i] = newUserResult(
+				users[i].GetId(),
+				pb.UserResultStatus_USER_RESULT_ALREADY_APPROVED,
+				existing.GetStatus(),
+				fmt.Sprintf("user is already approved by request %s", existing.GetReqID()),
+			)
+			continue
+		}
+
+		seenUsers[users[i].GetId()] = true
 		user := UserType{
 			ID:   users[i].GetId(),
 			Name: users[i].GetFullName(),
@@ -114,29 +206,20 @@ func (s Service) CreatePermit(ctx context.Context, req *pb.CreatePermitRequest)
 		userIDs = append(userIDs, user)
 	}
 
-	for i := 0; i < usersNum; i++ {
-		go func(i int) {
-			defer wg.Done()
-			_, err := s.controller.CreatePermit(ctx, reqID.String(), fileID, userIDs[i].ID, StatusPending)
-			if err != nil {
-				_ = fmt.Errorf("failed creating permit %s %s %v", fileID, users[i].GetId(), err)
-			}
-		}(i)
-	}
-
-	// TODO: get spike token. add header of authorization bearer
-	getSpikeTokenRequest := &spb.GetSpikeTokenRequest{
-		GrantType: s.grantType,
-		Audience:  s.audience,
+	for i, user := range users {
+		if results[i] == nil {
+			results[i] = newUserResult(user.GetId(), pb.UserResultStatus_USER_RESULT_CREATED, StatusPending, "")
+		}
 	}
 
-	tokenRes, err := s.spikeClient.GetSpikeToken(ctx, getSpikeTokenRequest)
-	if err != nil {
-		return nil, fmt.Errorf("failed getting spike token %v", err)
+	// No request is created if all of the users are already approved.
+	if len(userIDs) == 0 {
+		return &pb.CreatePermitResponse{
+			Results:  results,
+			Approval: &pb.ApprovalSubmission{Status: pb.SubmissionStatus_SUBMISSION_NONE},
+		}, nil
 	}
 
-	token := tokenRes.GetToken()
-
 	request := ApprovalReqType{
 		ID:             reqID.String(),
 		From:           sharerID,
@@ -146,83 +229,713 @@ func (s Service) CreatePermit(ctx context.Context, req *pb.CreatePermitRequest)
 		FileName:       fileName,
 		Info:           info,
 		Classification: classification,
+		ExpiresAt:      UnixMillis(expiresAt),
+		IdempotencyKey: idempotencyKey,
+		Results:        newStoredResults(results),
+	}
+
+	// The request, its permits and the approval request are stored in a single transaction,
+	// the approval request is then submitted to the approval service right away, and is hidden
+	// from the dispatcher for the duration of the attempt, which retries it if the attempt fails.
+	message := OutboxMessage{
+		Kind:          OutboxKindCreate,
+		ReqID:         reqID.String(),
+		Request:       request,
+		NextAttemptAt: now.Add(outboxLease),
+		CreatedAt:     now,
 	}
 
-	requestBody, err := json.Marshal(request)
+	// The permits are created atomically, a status error is returned if any of them failed.
+	change := StatusChange{Status: StatusPending, Actor: sharerID, Time: now}
+	permits, err := s.controller.CreatePermits(ctx, request, change, message)
+	if status.Code(err) == codes.AlreadyExists && idempotencyKey != "" {
+		// A concurrent retry of the request created it first.
+		existing, existingErr := s.existingPermitRequest(ctx, sharerID, idempotencyKey, fileID)
+		if existingErr != nil || existing != nil {
+			return existing, existingErr
+		}
+	}
 
 	if err != nil {
-		return nil, fmt.Errorf("failed creating json object, %v", err)
+		s.logger.Errorf("failed creating permits of file %s: %v", fileID, err)
+		return nil, err
+	}
+
+	events := make([]PermitEvent, 0, len(permits))
+	for _, permit := range permits {
+		events = append(events, newPermitEvent(reqID.String(), fileID, permit.GetUserID(), change))
+	}
+
+	s.publish(ctx, events...)
+
+	// A rejected approval request fails the permits, any other failure is retried by the dispatcher.
+	// The attempt ends with the lease of message, so it's over by the time the dispatcher may claim it.
+	submitCtx, cancel := context.WithTimeout(ctx, outboxLease)
+	approvalID, err := s.submitApprovalRequest(submitCtx, request)
+	cancel()
+
+	if err != nil && !IsPermanentError(err) {
+		s.logger.Errorf("failed submitting approval request %s, it will be retried: %v", reqID, err)
 	}
 
-	tr := &http.Transport{
-		TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+	if IsPermanentError(err) {
+		failCreatedResults(results, status.Convert(err).Message())
+	}
+
+	res := &pb.CreatePermitResponse{
+		ReqID:    reqID.String(),
+		Results:  results,
+		Approval: newApprovalSubmission(approvalID, err),
+	}
+
+	// The rejection is returned detailed with the response, so the caller still learns the reqID.
+	if IsPermanentError(err) {
+		return nil, withResponse(err, res)
+	}
+
+	return res, nil
+}
+
+// existingPermitRequest returns the response of the request of sharerID to fileID that was created
+// with idempotencyKey, or nil if there is no such request. Returns a codes.InvalidArgument status error
+// if idempotencyKey was used for a request to another file.
+func (s Service) existingPermitRequest(
+	ctx context.Context,
+	sharerID string,
+	idempotencyKey string,
+	fileID string,
+) (*pb.CreatePermitResponse, error) {
+	request, err := s.controller.GetPermitRequestByIdempotencyKey(ctx, sharerID, idempotencyKey)
+	if status.Code(err) == codes.NotFound {
+		return nil, nil
 	}
 
-	client := &http.Client{Transport: tr}
-	httpReq, err := http.NewRequest("POST", s.approvalURL, bytes.NewBuffer(requestBody))
 	if err != nil {
-		return nil, fmt.Errorf("error while creating http request to approval, %v", err)
+		return nil, err
+	}
+
+	if request.GetFileID() != fileID {
+		return nil, invalidArgument(
+			"idempotencyKey",
+			fmt.Sprintf("idempotencyKey was already used by request %s to another file", request.GetReqID()),
+		)
+	}
+
+	results, approval := storedPermitResults(request)
+	res := &pb.CreatePermitResponse{ReqID: request.GetReqID(), Results: results, Approval: approval}
+
+	// The rejection of the request is returned again, just like it was to the original request.
+	if approval.GetStatus() == pb.SubmissionStatus_SUBMISSION_REJECTED {
+		message := approval.GetMessage()
+		if message == "" {
+			message = fmt.Sprintf("approval service rejected approval request %s", request.GetReqID())
+		}
+
+		return nil, withResponse(status.Error(codes.FailedPrecondition, message), res)
+	}
+
+	return res, nil
+}
+
+// deliverApprovalRequest sends message to the approval service, filing its approval request
+// or cancelling the approval request of its reqID, depending on the kind of message.
+func (s Service) deliverApprovalRequest(ctx context.Context, message OutboxMessage) error {
+	var err error
+	switch message.Kind {
+	case OutboxKindCreate:
+		_, err = s.submitApprovalRequest(ctx, message.Request)
+	case OutboxKindCancel:
+		err = s.approvals.CancelApprovalRequest(ctx, message.ReqID)
+	default:
+		return status.Errorf(codes.Internal, "unknown outbox message kind %q", message.Kind)
+	}
+
+	// A rejected message would be rejected again if it were retried.
+	if IsPermanentError(err) {
+		s.logger.Errorf("approval service rejected %s of approval request %s: %v", message.Kind, message.ReqID, err)
+		return nil
 	}
-	httpReq.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
-	httpReq.Header.Set("Content-Type", "application/json")
-	resp, err := client.Do(httpReq)
+
+	return err
+}
+
+// submitApprovalRequest files request with the approval service, records the identifier it was given
+// and returns it. If the approval service rejects request, the pending permits of request are updated
+// to StatusFailedToSubmit and the rejection is returned.
+func (s Service) submitApprovalRequest(ctx context.Context, request ApprovalReqType) (string, error) {
+	approvalID, err := s.approvals.CreateApprovalRequest(ctx, request)
+
+	// The approval request was already filed by a previous attempt whose response was lost.
+	if status.Code(err) == codes.AlreadyExists {
+		err = nil
+	}
+
 	if err != nil {
-		return nil, fmt.Errorf("error while requesting from approval service %v", err)
+		if !IsPermanentError(err) {
+			return "", err
+		}
+
+		if failErr := s.failApprovalRequest(ctx, request.ID, err); failErr != nil {
+			return "", failErr
+		}
+
+		return "", err
+	}
+
+	if err := s.controller.CompleteApprovalRequest(ctx, request.ID, approvalID); err != nil {
+		return "", err
+	}
+
+	return approvalID, nil
+}
+
+// failApprovalRequest updates the pending permits of the request reqID to StatusFailedToSubmit,
+// after the approval service rejected their approval request with rejection.
+func (s Service) failApprovalRequest(ctx context.Context, reqID string, rejection error) error {
+	permits, err := s.controller.GetPermitsByReqID(ctx, reqID)
+	if err != nil {
+		return err
 	}
 
-	defer resp.Body.Close()
+	change := StatusChange{
+		Status: StatusFailedToSubmit,
+		Actor:  SystemActor,
+		Reason: status.Convert(rejection).Message(),
+		Time:   time.Now(),
+	}
 
-	return &pb.CreatePermitResponse{}, nil
+	failed, err := s.controller.FailApprovalRequest(ctx, reqID, StatusesTransitioningTo(StatusFailedToSubmit), change)
+	if err != nil {
+		return err
+	}
+
+	s.logger.Infof("%d permits of request %s failed to submit: %v", failed, reqID, rejection)
+	s.publish(ctx, changedPermitEvents(permits, change)...)
+
+	return nil
+}
+
+// RevokePermit is the request handler for withdrawing the approved permit of a user to a file,
+// the permit is kept with the revoked status and no longer grants access.
+func (s Service) RevokePermit(ctx context.Context, req *pb.RevokePermitRequest) (*pb.RevokePermitResponse, error) {
+	fileID := req.GetFileID()
+	userID := req.GetUserID()
+	if fileID == "" || userID == "" {
+		return nil, requiredFileIDAndUserID(fileID, userID, "")
+	}
+
+	if req.GetActor() == "" {
+		return nil, invalidArgument("actor", "actor is required")
+	}
+
+	permit, err := s.controller.GetPermit(ctx, fileID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !CanTransition(permit.GetStatus(), StatusRevoked) {
+		return nil, status.Errorf(codes.FailedPrecondition, "cannot revoke a %s permit", permit.GetStatus())
+	}
+
+	change := StatusChange{
+		Status: StatusRevoked,
+		Actor:  req.GetActor(),
+		Reason: req.GetReason(),
+		Time:   time.Now(),
+	}
+
+	revoked, err := s.controller.RevokePermit(ctx, fileID, userID, StatusesTransitioningTo(StatusRevoked), change)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(revoked) == 0 {
+		s.logger.Infof("permit of user %s to file %s is already revoked", userID, fileID)
+		return &pb.RevokePermitResponse{}, nil
+	}
+
+	s.publish(ctx, changedPermitEvents(revoked, change)...)
+
+	return &pb.RevokePermitResponse{}, nil
+}
+
+// CancelPermitRequest is the request handler for cancelling the pending permits of a request by its sharer,
+// the approval service is notified of the cancellation.
+func (s Service) CancelPermitRequest(
+	ctx context.Context,
+	req *pb.CancelPermitRequestRequest,
+) (*pb.CancelPermitRequestResponse, error) {
+	reqID := req.GetReqID()
+	actor := req.GetActor()
+	if reqID == "" {
+		return nil, invalidArgument("reqID", "reqID is required")
+	}
+
+	if actor == "" {
+		return nil, invalidArgument("actor", "actor is required")
+	}
+
+	request, err := s.controller.GetPermitRequest(ctx, reqID)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.GetSharerID() != actor {
+		return nil, status.Errorf(codes.PermissionDenied, "only the sharer of request %s may cancel it", reqID)
+	}
+
+	permits, err := s.controller.GetPermitsByReqID(ctx, reqID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	change := StatusChange{Status: StatusCancelled, Actor: actor, Time: now}
+	message := OutboxMessage{
+		Kind:          OutboxKindCancel,
+		ReqID:         reqID,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+	}
+
+	cancelled, err := s.controller.CancelPermits(ctx, reqID, StatusesTransitioningTo(StatusCancelled), change, message)
+	if err != nil {
+		return nil, err
+	}
+
+	if cancelled == 0 {
+		return nil, status.Errorf(codes.FailedPrecondition, "request %s has no pending permits", reqID)
+	}
+
+	s.dispatcher.Notify()
+	s.publish(ctx, changedPermitEvents(permits, change)...)
+
+	return &pb.CancelPermitRequestResponse{}, nil
 }
 
 // GetPermitByFileID is the request handler for getting a permit (user, status) by file id.
 func (s Service) GetPermitByFileID(ctx context.Context, req *pb.GetPermitByFileIDRequest) (*pb.GetPermitByFileIDResponse, error) {
 	fileID := req.GetFileID()
 	if fileID == "" {
-		return nil, fmt.Errorf("fileID is required")
+		return nil, invalidArgument("fileID", "fileID is required")
 	}
 
 	userStatuses, err := s.controller.GetPermitsByFileID(ctx, fileID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to retrieve permit %v", err)
+		return nil, err
 	}
 
 	return &pb.GetPermitByFileIDResponse{UserStatus: userStatuses}, nil
 }
 
-// HasPermit is the request handler for checking if a permit exists for fileID and userID.
+// HasPermit is the request handler for checking if a user is permitted to access a file.
+// Only an approved and unexpired permit grants access, the response holds the decision
+// along with the reqID and status of the permit it was made by.
 func (s Service) HasPermit(ctx context.Context, req *pb.HasPermitRequest) (*pb.HasPermitResponse, error) {
 	fileID := req.GetFileID()
 	userID := req.GetUserID()
 	if fileID == "" || userID == "" {
-		return nil, fmt.Errorf("fileID and userID are required")
+		return nil, requiredFileIDAndUserID(fileID, userID, "")
 	}
 
-	hasPermit, err := s.controller.HasPermit(ctx, fileID, userID)
+	permit, err := s.controller.GetPermit(ctx, fileID, userID)
+	if err != nil && status.Code(err) != codes.NotFound {
+		return nil, err
+	}
+
+	if status.Code(err) == codes.NotFound {
+		return newHasPermitResponse(nil, time.Now()), nil
+	}
+
+	return newHasPermitResponse(permit, time.Now()), nil
+}
+
+// HasPermitBatch is the request handler for checking if users are permitted to access files,
+// for many pairs of fileID and userID at once. The results are in the order of the request's pairs.
+func (s Service) HasPermitBatch(ctx context.Context, req *pb.HasPermitBatchRequest) (*pb.HasPermitBatchResponse, error) {
+	pairs := req.GetPermits()
+	if len(pairs) == 0 {
+		return nil, invalidArgument("permits", "at least one permit is required")
+	}
+
+	if len(pairs) > MaxBatchSize {
+		return nil, invalidArgument("permits", fmt.Sprintf("at most %d permits may be checked at once", MaxBatchSize))
+	}
+
+	keys := make([]PermitKey, 0, len(pairs))
+	for i, pair := range pairs {
+		if pair.GetFileID() == "" || pair.GetUserID() == "" {
+			return nil, requiredFileIDAndUserID(pair.GetFileID(), pair.GetUserID(), fmt.Sprintf("permits[%d].", i))
+		}
+
+		keys = append(keys, PermitKey{FileID: pair.GetFileID(), UserID: pair.GetUserID()})
+	}
+
+	permits, err := s.controller.GetPermitsByKeys(ctx, keys)
 	if err != nil {
-		return nil, fmt.Errorf("failed in reqesting permit %v", err)
+		return nil, err
 	}
 
-	return &pb.HasPermitResponse{HasPermit: hasPermit}, nil
+	now := time.Now()
+	results := make([]*pb.HasPermitResponse, 0, len(keys))
+	for _, key := range keys {
+		results = append(results, newHasPermitResponse(permits[key], now))
+	}
+
+	return &pb.HasPermitBatchResponse{Results: results}, nil
 }
 
-// UpdatePermitStatus is the request handler for updating the status of a given permit.
-func (s Service) UpdatePermitStatus(ctx context.Context, req *pb.UpdatePermitStatusRequest) (*pb.UpdatePermitStatusResponse, error) {
+// newHasPermitResponse returns the response of checking if permit grants access at now,
+// a nil permit doesn't grant access.
+func newHasPermitResponse(permit Permit, now time.Time) *pb.HasPermitResponse {
+	if permit == nil {
+		return &pb.HasPermitResponse{HasPermit: false, Decision: pb.Decision_DECISION_NONE}
+	}
+
+	decision := Decide(permit, now)
+
+	return &pb.HasPermitResponse{
+		HasPermit: decision == pb.Decision_DECISION_GRANTED,
+		Decision:  decision,
+		ReqID:     permit.GetReqID(),
+		Status:    permit.GetStatus(),
+	}
+}
+
+// ListPermitsByUser is the request handler for listing the permits of a user, page by page.
+func (s Service) ListPermitsByUser(
+	ctx context.Context,
+	req *pb.ListPermitsByUserRequest,
+) (*pb.ListPermitsByUserResponse, error) {
+	userID := req.GetUserID()
+	if userID == "" {
+		return nil, invalidArgument("userID", "userID is required")
+	}
+
+	statuses, err := parseStatuses(req.GetStatuses())
+	if err != nil {
+		return nil, err
+	}
+
+	size, err := pageSize(req.GetPageSize())
+	if err != nil {
+		return nil, err
+	}
+
+	permits, nextPageToken, err := s.controller.ListPermitsByUser(ctx, userID, statuses, size, req.GetPageToken())
+	if err != nil {
+		return nil, pageTokenError(err)
+	}
+
+	permitObjects := make([]*pb.PermitObject, 0, len(permits))
+	for _, permit := range permits {
+		permitObject := &pb.PermitObject{}
+		if err := permit.MarshalProto(permitObject); err != nil {
+			return nil, internalError(err, fmt.Sprintf("failed marshaling permit %s", permit.GetID()))
+		}
+
+		permitObjects = append(permitObjects, permitObject)
+	}
+
+	return &pb.ListPermitsByUserResponse{Permits: permitObjects, NextPageToken: nextPageToken}, nil
+}
+
+// ListPermitRequestsBySharer is the request handler for listing the permit requests a user shared,
+// along with the status of each of their users, page by page.
+func (s Service) ListPermitRequestsBySharer(
+	ctx context.Context,
+	req *pb.ListPermitRequestsBySharerRequest,
+) (*pb.ListPermitRequestsBySharerResponse, error) {
+	sharerID := req.GetSharerID()
+	if sharerID == "" {
+		return nil, invalidArgument("sharerID", "sharerID is required")
+	}
+
+	statuses, err := parseStatuses(req.GetStatuses())
+	if err != nil {
+		return nil, err
+	}
+
+	size, err := pageSize(req.GetPageSize())
+	if err != nil {
+		return nil, err
+	}
+
+	requests, nextPageToken, err := s.controller.ListPermitRequestsBySharer(
+		ctx,
+		sharerID,
+		statuses,
+		size,
+		req.GetPageToken(),
+	)
+	if err != nil {
+		return nil, pageTokenError(err)
+	}
+
+	requestObjects, err := marshalRequests(requests)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.ListPermitRequestsBySharerResponse{Requests: requestObjects, NextPageToken: nextPageToken}, nil
+}
+
+// ListPendingApprovals is the request handler for listing the permit requests that are waiting for
+// an approver's decision, along with the status of each of their users, page by page.
+func (s Service) ListPendingApprovals(
+	ctx context.Context,
+	req *pb.ListPendingApprovalsRequest,
+) (*pb.ListPendingApprovalsResponse, error) {
+	approverID := req.GetApproverID()
+	if approverID == "" {
+		return nil, invalidArgument("approverID", "approverID is required")
+	}
+
+	size, err := pageSize(req.GetPageSize())
+	if err != nil {
+		return nil, err
+	}
+
+	requests, nextPageToken, err := s.controller.ListPendingApprovals(ctx, approverID, size, req.GetPageToken())
+	if err != nil {
+		return nil, pageTokenError(err)
+	}
+
+	requestObjects, err := marshalRequests(requests)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.ListPendingApprovalsResponse{Requests: requestObjects, NextPageToken: nextPageToken}, nil
+}
+
+// WatchPermits is the request handler for streaming the permits that match the request's filter
+// whenever they are created or their status changes, until the client cancels the stream.
+func (s Service) WatchPermits(req *pb.WatchPermitsRequest, stream pb.Permit_WatchPermitsServer) error {
+	filter := WatchFilter{
+		FileID: req.GetFileID(),
+		UserID: req.GetUserID(),
+		ReqID:  req.GetReqID(),
+	}
+
+	if filter.FileID == "" && filter.UserID == "" && filter.ReqID == "" {
+		return invalidArgument("fileID", "at least one of fileID, userID and reqID is required")
+	}
+
+	return s.controller.WatchPermits(stream.Context(), filter, func(permit Permit) error {
+		permitObject := &pb.PermitObject{}
+		if err := permit.MarshalProto(permitObject); err != nil {
+			return internalError(err, fmt.Sprintf("failed marshaling permit %s", permit.GetID()))
+		}
+
+		return stream.Send(&pb.WatchPermitsResponse{Permit: permitObject})
+	})
+}
+
+// GetPermitRequest is the request handler for getting a permit request by its reqID,
+// along with the current status of each of its users.
+func (s Service) GetPermitRequest(ctx context.Context, req *pb.GetPermitRequestRequest) (*pb.GetPermitRequestResponse, error) {
 	reqID := req.GetReqID()
-	status := req.GetStatus()
+	if reqID == "" {
+		return nil, invalidArgument("reqID", "reqID is required")
+	}
+
+	request, err := s.controller.GetPermitRequest(ctx, reqID)
+	if err != nil {
+		return nil, err
+	}
+
+	permits, err := s.controller.GetPermitsByReqID(ctx, reqID)
+	if err != nil && status.Code(err) != codes.NotFound {
+		return nil, err
+	}
+
+	requestObject := &pb.PermitRequestObject{}
+	if err := (RequestPermits{Request: request, Permits: permits}).MarshalProto(requestObject); err != nil {
+		return nil, internalError(err, fmt.Sprintf("failed marshaling request %s", reqID))
+	}
 
+	return &pb.GetPermitRequestResponse{Request: requestObject}, nil
+}
+
+// UpdatePermitStatus is the request handler for an approver's decision on the permits of a request.
+// The status must be either approved or denied, cancelling and revoking permits is done by
+// CancelPermitRequest and RevokePermit. Every permit of the request must be allowed to transition
+// to the status, otherwise none of the permits are updated.
+// If the request has decisions, the permit of each of their users is updated to the status
+// of its decision instead, either all of them are updated or none of them are.
+func (s Service) UpdatePermitStatus(ctx context.Context, req *pb.UpdatePermitStatusRequest) (*pb.UpdatePermitStatusResponse, error) {
+	reqID := req.GetReqID()
 	if reqID == "" {
-		return nil, fmt.Errorf("reqID is required")
+		return nil, invalidArgument("reqID", "reqID is required")
+	}
+
+	if len(req.GetDecisions()) > 0 {
+		if req.GetStatus() != "" {
+			return nil, invalidArgument("status", "only one of status and decisions may be given")
+		}
+
+		return s.updateUserPermitStatuses(ctx, req)
+	}
+
+	newStatus, err := ParseDecision(req.GetStatus())
+	if err != nil {
+		return nil, invalidArgument("status", err.Error())
+	}
+
+	permits, err := s.controller.GetPermitsByReqID(ctx, reqID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, permit := range permits {
+		if !CanTransition(permit.GetStatus(), newStatus) {
+			return nil, status.Errorf(
+				codes.FailedPrecondition,
+				"permit of user %s cannot transition from %s to %s",
+				permit.GetUserID(),
+				permit.GetStatus(),
+				newStatus,
+			)
+		}
+	}
+
+	change := StatusChange{
+		Status: newStatus,
+		Actor:  req.GetActor(),
+		Reason: req.GetReason(),
+		Time:   time.Now(),
 	}
 
-	ok, err := s.controller.UpdatePermitStatus(ctx, reqID, status)
+	ok, err := s.controller.UpdatePermitStatus(ctx, reqID, StatusesTransitioningTo(newStatus), change)
 	if err != nil {
-		return nil, fmt.Errorf("update permit status failed %v", err)
+		return nil, err
 	}
 
 	if !ok {
-		_ = fmt.Errorf("error updating permit status")
+		s.logger.Infof("permits of request %s already have status %s", reqID, newStatus)
+		return &pb.UpdatePermitStatusResponse{}, nil
 	}
 
+	s.publish(ctx, changedPermitEvents(permits, change)...)
+
 	return &pb.UpdatePermitStatusResponse{}, nil
 }
+
+// updateUserPermitStatuses updates the permit of each user of the decisions of req to the status of
+// its decision. A decision without a reason is given the reason of req.
+func (s Service) updateUserPermitStatuses(
+	ctx context.Context,
+	req *pb.UpdatePermitStatusRequest,
+) (*pb.UpdatePermitStatusResponse, error) {
+	reqID := req.GetReqID()
+	permits, err := s.controller.GetPermitsByReqID(ctx, reqID)
+	if err != nil {
+		return nil, err
+	}
+
+	permitsByUser := make(map[string]Permit, len(permits))
+	for _, permit := range permits {
+		permitsByUser[permit.GetUserID()] = permit
+	}
+
+	now := time.Now()
+	updates := make([]UserStatusUpdate, 0, len(req.GetDecisions()))
+	events := make([]PermitEvent, 0, len(req.GetDecisions()))
+	decided := make(map[string]bool, len(req.GetDecisions()))
+	for i, decision := range req.GetDecisions() {
+		userID := decision.GetUserID()
+		if userID == "" {
+			return nil, invalidArgument(fmt.Sprintf("decisions[%d].userID", i), fmt.Sprintf("decisions[%d] is missing a userID", i))
+		}
+
+		if decided[userID] {
+			return nil, invalidArgument(fmt.Sprintf("decisions[%d].userID", i), fmt.Sprintf("user %s has more than one decision", userID))
+		}
+
+		decided[userID] = true
+		newStatus, err := ParseDecision(decision.GetStatus())
+		if err != nil {
+			return nil, invalidArgument(fmt.Sprintf("decisions[%d].status", i), err.Error())
+		}
+
+		permit, ok := permitsByUser[userID]
+		if !ok {
+			return nil, status.Errorf(codes.NotFound, "user %s has no permit in request %s", userID, reqID)
+		}
+
+		if !CanTransition(permit.GetStatus(), newStatus) {
+			return nil, status.Errorf(
+				codes.FailedPrecondition,
+				"permit of user %s cannot transition from %s to %s",
+				userID,
+				permit.GetStatus(),
+				newStatus,
+			)
+		}
+
+		reason := decision.GetReason()
+		if reason == "" {
+			reason = req.GetReason()
+		}
+
+		change := StatusChange{
+			Status: newStatus,
+			Actor:  req.GetActor(),
+			Reason: reason,
+			Time:   now,
+		}
+
+		updates = append(updates, UserStatusUpdate{
+			UserID:       userID,
+			FromStatuses: StatusesTransitioningTo(newStatus),
+			Change:       change,
+		})
+
+		events = append(events, changedPermitEvents([]Permit{permit}, change)...)
+	}
+
+	if _, err := s.controller.UpdateUserPermitStatuses(ctx, reqID, updates); err != nil {
+		return nil, err
+	}
+
+	s.publish(ctx, events...)
+
+	return &pb.UpdatePermitStatusResponse{}, nil
+}
+
+// requiredFileIDAndUserID returns a codes.InvalidArgument status error of each of fileID and userID
+// that is missing, prefix is prepended to the name of the fields.
+func requiredFileIDAndUserID(fileID string, userID string, prefix string) error {
+	var violations []*errdetails.BadRequest_FieldViolation
+	if fileID == "" {
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{
+			Field:       prefix + "fileID",
+			Description: prefix + "fileID is required",
+		})
+	}
+
+	if userID == "" {
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{
+			Field:       prefix + "userID",
+			Description: prefix + "userID is required",
+		})
+	}
+
+	return badRequest(violations...)
+}
+
+// marshalRequests marshals each of requests into a permit request.
+func marshalRequests(requests []RequestPermits) ([]*pb.PermitRequestObject, error) {
+	requestObjects := make([]*pb.PermitRequestObject, 0, len(requests))
+	for _, request := range requests {
+		requestObject := &pb.PermitRequestObject{}
+		if err := request.MarshalProto(requestObject); err != nil {
+			return nil, internalError(err, fmt.Sprintf("failed marshaling request %s", request.Request.GetReqID()))
+		}
+
+		requestObjects = append(requestObjects, requestObject)
+	}
+
+	return requestObjects, nil
+}
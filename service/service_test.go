@@ -0,0 +1,729 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	pb "github.com/meateam/permit-service/proto"
+	"github.com/meateam/permit-service/service"
+	"github.com/meateam/permit-service/service/approval"
+	"github.com/meateam/permit-service/service/mongodb"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	testReqID    = "req"
+	testFileID   = "file"
+	testSharerID = "sharer"
+)
+
+// stubController is a Controller of permits, permit requests and an outbox that records
+// the updates made to them, its other methods panic.
+type stubController struct {
+	service.Controller
+	permits  []service.Permit
+	requests []service.ApprovalReqType
+	updates  []service.UserStatusUpdate
+	changes  []service.StatusChange
+
+	// outbox holds the due outbox messages, drained is called once they were all claimed.
+	outbox    []service.OutboxMessage
+	drained   func()
+	retried   []string
+	deleted   []string
+	completed []string
+
+	// rejections holds the messages the approval requests were rejected with by their reqIDs.
+	rejections map[string]string
+}
+
+func (c *stubController) CreatePermits(
+	ctx context.Context,
+	request service.ApprovalReqType,
+	change service.StatusChange,
+	message service.OutboxMessage,
+) ([]service.Permit, error) {
+	if request.IdempotencyKey != "" {
+		if _, err := c.GetPermitRequestByIdempotencyKey(ctx, request.From, request.IdempotencyKey); err == nil {
+			return nil, status.Error(codes.AlreadyExists, "idempotencyKey was already used")
+		}
+	}
+
+	c.requests = append(c.requests, request)
+	permits := make([]service.Permit, 0, len(request.To))
+	for _, user := range request.To {
+		permits = append(permits, &mongodb.BSON{
+			ReqID:  request.ID,
+			FileID: request.FileID,
+			UserID: user.ID,
+			Status: change.Status,
+		})
+	}
+
+	c.permits = append(c.permits, permits...)
+	return permits, nil
+}
+
+func (c *stubController) GetPermitRequestByIdempotencyKey(
+	ctx context.Context,
+	sharerID string,
+	idempotencyKey string,
+) (service.PermitRequest, error) {
+	for _, request := range c.requests {
+		if request.From == sharerID && request.IdempotencyKey == idempotencyKey {
+			return c.requestBSON(request), nil
+		}
+	}
+
+	return nil, status.Error(codes.NotFound, "request not found")
+}
+
+// requestBSON returns request as it's stored along with the submission of its approval request.
+func (c *stubController) requestBSON(request service.ApprovalReqType) mongodb.RequestBSON {
+	stored := mongodb.RequestBSON{
+		ReqID:          request.ID,
+		FileID:         request.FileID,
+		SharerID:       request.From,
+		IdempotencyKey: request.IdempotencyKey,
+		Submission:     service.SubmissionQueued,
+	}
+
+	for _, result := range request.Results {
+		stored.Results = append(stored.Results, mongodb.UserResultBSON(result))
+	}
+
+	for _, reqID := range c.completed {
+		if reqID == request.ID {
+			stored.Submission = service.SubmissionSubmitted
+			stored.ApprovalID = reqID
+		}
+	}
+
+	if message, ok := c.rejections[request.ID]; ok {
+		stored.Submission = service.SubmissionRejected
+		stored.SubmissionMessage = message
+	}
+
+	return stored
+}
+
+func (c *stubController) GetPermitsByReqID(ctx context.Context, reqID string) ([]service.Permit, error) {
+	var permits []service.Permit
+	for _, permit := range c.permits {
+		if permit.GetReqID() == reqID {
+			permits = append(permits, permit)
+		}
+	}
+
+	return permits, nil
+}
+
+func (c *stubController) UpdateUserPermitStatuses(
+	ctx context.Context,
+	reqID string,
+	updates []service.UserStatusUpdate,
+) (int64, error) {
+	c.updates = append(c.updates, updates...)
+	return int64(len(updates)), nil
+}
+
+func (c *stubController) GetPermit(ctx context.Context, fileID string, userID string) (service.Permit, error) {
+	for _, permit := range c.permits {
+		if permit.GetFileID() == fileID && permit.GetUserID() == userID {
+			return permit, nil
+		}
+	}
+
+	return nil, status.Error(codes.NotFound, "permit not found")
+}
+
+func (c *stubController) GetPermitsByKeys(
+	ctx context.Context,
+	keys []service.PermitKey,
+) (map[service.PermitKey]service.Permit, error) {
+	permits := make(map[service.PermitKey]service.Permit, len(keys))
+	for _, key := range keys {
+		if permit, err := c.GetPermit(ctx, key.FileID, key.UserID); err == nil {
+			permits[key] = permit
+		}
+	}
+
+	return permits, nil
+}
+
+func (c *stubController) RevokePermit(
+	ctx context.Context,
+	fileID string,
+	userID string,
+	fromStatuses []string,
+	change service.StatusChange,
+) ([]service.Permit, error) {
+	c.changes = append(c.changes, change)
+	return c.permits, nil
+}
+
+func (c *stubController) ExpirePermits(
+	ctx context.Context,
+	fromStatuses []string,
+	change service.StatusChange,
+) ([]service.Permit, error) {
+	c.changes = append(c.changes, change)
+	return c.permits, nil
+}
+
+func (c *stubController) ClaimOutboxMessage(
+	ctx context.Context,
+	now time.Time,
+	lease time.Duration,
+) (service.OutboxMessage, error) {
+	if len(c.outbox) == 0 {
+		c.drained()
+		return service.OutboxMessage{}, status.Error(codes.NotFound, "no due outbox messages")
+	}
+
+	message := c.outbox[0]
+	c.outbox = c.outbox[1:]
+	message.Attempts++
+
+	return message, nil
+}
+
+func (c *stubController) RetryOutboxMessage(ctx context.Context, id string, nextAttemptAt time.Time, lastError string) error {
+	c.retried = append(c.retried, id)
+	return nil
+}
+
+func (c *stubController) DeleteOutboxMessage(ctx context.Context, id string) error {
+	c.deleted = append(c.deleted, id)
+	return nil
+}
+
+func (c *stubController) CompleteApprovalRequest(ctx context.Context, reqID string, approvalID string) error {
+	c.completed = append(c.completed, reqID)
+	return nil
+}
+
+func (c *stubController) FailApprovalRequest(
+	ctx context.Context,
+	reqID string,
+	fromStatuses []string,
+	change service.StatusChange,
+) (int64, error) {
+	if c.rejections == nil {
+		c.rejections = map[string]string{}
+	}
+
+	c.rejections[reqID] = change.Reason
+	c.changes = append(c.changes, change)
+	return int64(len(c.permits)), nil
+}
+
+// recordingPublisher is a service.EventPublisher that records the published events.
+type recordingPublisher struct {
+	events []service.PermitEvent
+}
+
+func (p *recordingPublisher) Publish(ctx context.Context, events ...service.PermitEvent) error {
+	p.events = append(p.events, events...)
+	return nil
+}
+
+func (p *recordingPublisher) Close() error {
+	return nil
+}
+
+// newTestService returns a Service of controller that discards its logs.
+func newTestService(controller service.Controller) service.Service {
+	return newTestServiceWith(controller, approval.NewFakeClient(), nil)
+}
+
+// newPublishingTestService returns a Service of controller that publishes its events with publisher
+// and discards its logs.
+func newPublishingTestService(controller service.Controller, publisher service.EventPublisher) service.Service {
+	return newTestServiceWith(controller, approval.NewFakeClient(), publisher)
+}
+
+// newTestServiceWith returns a Service of controller that files its approval requests with approvals,
+// publishes its events with publisher and discards its logs.
+func newTestServiceWith(
+	controller service.Controller,
+	approvals service.ApprovalClient,
+	publisher service.EventPublisher,
+) service.Service {
+	logger := logrus.New()
+	logger.SetOutput(ioutil.Discard)
+
+	return service.NewService(controller, logger, approvals, publisher)
+}
+
+// newCreatePermitRequest returns the request of creating the permits of testFileID to userIDs by testSharerID.
+func newCreatePermitRequest(userIDs ...string) *pb.CreatePermitRequest {
+	users := make([]*pb.User, 0, len(userIDs))
+	for _, userID := range userIDs {
+		users = append(users, &pb.User{Id: userID, FullName: userID})
+	}
+
+	return &pb.CreatePermitRequest{
+		FileID:    testFileID,
+		FileName:  "file.txt",
+		SharerID:  testSharerID,
+		Users:     users,
+		Approvers: []string{"approver"},
+	}
+}
+
+// checkResults fails t if the results of the users of a CreatePermit response aren't want.
+func checkResults(t *testing.T, results []*pb.UserResult, want []pb.UserResultStatus) {
+	t.Helper()
+
+	if len(results) != len(want) {
+		t.Fatalf("CreatePermit() returned %d results, want %d", len(results), len(want))
+	}
+
+	for i, result := range results {
+		if result.GetResult() != want[i] {
+			t.Errorf("CreatePermit() result %d = %v, want %v", i, result.GetResult(), want[i])
+		}
+	}
+}
+
+// dispatchOutbox delivers the messages in the outbox of controller with s until it's drained.
+func dispatchOutbox(s service.Service, controller *stubController) {
+	ctx, cancel := context.WithCancel(context.Background())
+	controller.drained = cancel
+	s.DispatchOutbox(ctx, time.Hour, time.Minute)
+}
+
+// newStubController returns a stubController of the permits of testReqID with
+// the statuses of their users in statuses.
+func newStubController(statuses map[string]string) *stubController {
+	controller := &stubController{}
+	for userID, permitStatus := range statuses {
+		controller.permits = append(controller.permits, &mongodb.BSON{
+			ReqID:  testReqID,
+			FileID: testFileID,
+			UserID: userID,
+			Status: permitStatus,
+		})
+	}
+
+	return controller
+}
+
+func TestUpdatePermitStatusDecisions(t *testing.T) {
+	controller := newStubController(map[string]string{
+		"a": service.StatusPending,
+		"b": service.StatusPending,
+	})
+
+	_, err := newTestService(controller).UpdatePermitStatus(context.Background(), &pb.UpdatePermitStatusRequest{
+		ReqID:  testReqID,
+		Actor:  "approver",
+		Reason: "shared reason",
+		Decisions: []*pb.UserDecision{
+			{UserID: "a", Status: service.StatusApproved},
+			{UserID: "b", Status: service.StatusDenied, Reason: "own reason"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("UpdatePermitStatus() failed: %v", err)
+	}
+
+	want := []struct {
+		userID string
+		status string
+		reason string
+	}{
+		{"a", service.StatusApproved, "shared reason"},
+		{"b", service.StatusDenied, "own reason"},
+	}
+
+	if len(controller.updates) != len(want) {
+		t.Fatalf("UpdatePermitStatus() made %d updates, want %d", len(controller.updates), len(want))
+	}
+
+	for i, w := range want {
+		update := controller.updates[i]
+		if update.UserID != w.userID || update.Change.Status != w.status || update.Change.Reason != w.reason {
+			t.Errorf(
+				"update %d = %s to %s (%q), want %s to %s (%q)",
+				i, update.UserID, update.Change.Status, update.Change.Reason, w.userID, w.status, w.reason,
+			)
+		}
+
+		if update.Change.Actor != "approver" {
+			t.Errorf("update %d actor = %q, want %q", i, update.Change.Actor, "approver")
+		}
+	}
+}
+
+func TestUpdatePermitStatusInvalidDecisions(t *testing.T) {
+	tests := []struct {
+		name string
+		req  *pb.UpdatePermitStatusRequest
+		want codes.Code
+	}{
+		{
+			name: "status and decisions",
+			req: &pb.UpdatePermitStatusRequest{
+				Status:    service.StatusApproved,
+				Decisions: []*pb.UserDecision{{UserID: "a", Status: service.StatusApproved}},
+			},
+			want: codes.InvalidArgument,
+		},
+		{
+			name: "missing userID",
+			req:  &pb.UpdatePermitStatusRequest{Decisions: []*pb.UserDecision{{Status: service.StatusApproved}}},
+			want: codes.InvalidArgument,
+		},
+		{
+			name: "duplicate user",
+			req: &pb.UpdatePermitStatusRequest{Decisions: []*pb.UserDecision{
+				{UserID: "a", Status: service.StatusApproved},
+				{UserID: "a", Status: service.StatusDenied},
+			}},
+			want: codes.InvalidArgument,
+		},
+		{
+			name: "not a decision",
+			req:  &pb.UpdatePermitStatusRequest{Decisions: []*pb.UserDecision{{UserID: "a", Status: service.StatusRevoked}}},
+			want: codes.InvalidArgument,
+		},
+		{
+			name: "user without a permit",
+			req:  &pb.UpdatePermitStatusRequest{Decisions: []*pb.UserDecision{{UserID: "c", Status: service.StatusApproved}}},
+			want: codes.NotFound,
+		},
+		{
+			name: "decided permit",
+			req: &pb.UpdatePermitStatusRequest{Decisions: []*pb.UserDecision{
+				{UserID: "a", Status: service.StatusApproved},
+				{UserID: "b", Status: service.StatusApproved},
+			}},
+			want: codes.FailedPrecondition,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := newStubController(map[string]string{
+				"a": service.StatusPending,
+				"b": service.StatusDenied,
+			})
+
+			tt.req.ReqID = testReqID
+			_, err := newTestService(controller).UpdatePermitStatus(context.Background(), tt.req)
+			if status.Code(err) != tt.want {
+				t.Errorf("UpdatePermitStatus() error = %v, want code %v", err, tt.want)
+			}
+
+			if len(controller.updates) != 0 {
+				t.Errorf("UpdatePermitStatus() made %d updates, want none", len(controller.updates))
+			}
+		})
+	}
+}
+
+func TestRevokePermit(t *testing.T) {
+	controller := newStubController(map[string]string{"a": service.StatusApproved})
+	publisher := &recordingPublisher{}
+	s := newPublishingTestService(controller, publisher)
+
+	_, err := s.RevokePermit(context.Background(), &pb.RevokePermitRequest{FileID: testFileID, UserID: "a"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("RevokePermit() without an actor error = %v, want code %v", err, codes.InvalidArgument)
+	}
+
+	// The user has a permit left over from an earlier request that is revoked along with it.
+	controller.permits = append(controller.permits, &mongodb.BSON{
+		ReqID:  "earlier",
+		FileID: testFileID,
+		UserID: "a",
+		Status: service.StatusApproved,
+	})
+
+	_, err = s.RevokePermit(context.Background(), &pb.RevokePermitRequest{FileID: testFileID, UserID: "a", Actor: "owner"})
+	if err != nil {
+		t.Fatalf("RevokePermit() failed: %v", err)
+	}
+
+	if len(controller.changes) != 1 || controller.changes[0].Actor != "owner" {
+		t.Fatalf("RevokePermit() made changes %v, want a single change by owner", controller.changes)
+	}
+
+	if len(publisher.events) != 2 {
+		t.Fatalf("RevokePermit() published %d events, want 2", len(publisher.events))
+	}
+
+	for i, reqID := range []string{testReqID, "earlier"} {
+		event := publisher.events[i]
+		if event.Type != service.EventType(service.StatusRevoked) || event.ReqID != reqID {
+			t.Errorf("event %d = %s of %s, want %s of %s", i, event.Type, event.ReqID, service.EventType(service.StatusRevoked), reqID)
+		}
+	}
+}
+
+func TestSweepExpiredPermits(t *testing.T) {
+	controller := newStubController(map[string]string{
+		"a": service.StatusApproved,
+		"b": service.StatusPending,
+	})
+	publisher := &recordingPublisher{}
+
+	// The sweep runs once before the done context is noticed.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	newPublishingTestService(controller, publisher).SweepExpiredPermits(ctx, time.Hour)
+
+	if len(controller.changes) != 1 || controller.changes[0].Status != service.StatusExpired {
+		t.Fatalf("SweepExpiredPermits() made changes %v, want a single change to %s", controller.changes, service.StatusExpired)
+	}
+
+	if len(publisher.events) != len(controller.permits) {
+		t.Fatalf("SweepExpiredPermits() published %d events, want %d", len(publisher.events), len(controller.permits))
+	}
+
+	for i, event := range publisher.events {
+		if event.Type != service.EventType(service.StatusExpired) || event.UserID != controller.permits[i].GetUserID() {
+			t.Errorf(
+				"event %d = %s of user %s, want %s of user %s",
+				i, event.Type, event.UserID, service.EventType(service.StatusExpired), controller.permits[i].GetUserID(),
+			)
+		}
+	}
+}
+
+func TestDispatchOutbox(t *testing.T) {
+	controller := &stubController{outbox: []service.OutboxMessage{
+		{ID: "1", Kind: service.OutboxKindCreate, ReqID: testReqID, Request: service.ApprovalReqType{ID: testReqID}},
+		{ID: "2", Kind: service.OutboxKindCancel, ReqID: "cancelled"},
+	}}
+	approvals := approval.NewFakeClient()
+
+	dispatchOutbox(newTestServiceWith(controller, approvals, nil), controller)
+
+	if created := approvals.Created(); len(created) != 1 || created[0].ID != testReqID {
+		t.Errorf("DispatchOutbox() filed approval requests %v, want %s", created, testReqID)
+	}
+
+	if cancelled := approvals.Cancelled(); len(cancelled) != 1 || cancelled[0] != "cancelled" {
+		t.Errorf("DispatchOutbox() cancelled approval requests %v, want cancelled", cancelled)
+	}
+
+	if len(controller.deleted) != 2 || len(controller.retried) != 0 {
+		t.Errorf("DispatchOutbox() deleted %v and retried %v, want all deleted", controller.deleted, controller.retried)
+	}
+
+	if len(controller.completed) != 1 || controller.completed[0] != testReqID {
+		t.Errorf("DispatchOutbox() completed approval requests %v, want %s", controller.completed, testReqID)
+	}
+}
+
+func TestDispatchOutboxRejected(t *testing.T) {
+	controller := newStubController(map[string]string{"a": service.StatusPending})
+	controller.outbox = []service.OutboxMessage{
+		{ID: "1", Kind: service.OutboxKindCreate, ReqID: testReqID, Request: service.ApprovalReqType{ID: testReqID}},
+	}
+	approvals := approval.NewFakeClient()
+	approvals.SetError(status.Error(codes.FailedPrecondition, "file is classified"))
+	publisher := &recordingPublisher{}
+
+	dispatchOutbox(newTestServiceWith(controller, approvals, publisher), controller)
+
+	// A rejected approval request is not retried, its permits fail to submit instead.
+	if len(controller.deleted) != 1 || len(controller.retried) != 0 {
+		t.Errorf("DispatchOutbox() deleted %v and retried %v, want all deleted", controller.deleted, controller.retried)
+	}
+
+	if len(controller.changes) != 1 || controller.changes[0].Status != service.StatusFailedToSubmit {
+		t.Fatalf("DispatchOutbox() made changes %v, want a single change to %s", controller.changes, service.StatusFailedToSubmit)
+	}
+
+	if controller.changes[0].Reason != "file is classified" {
+		t.Errorf("DispatchOutbox() change reason = %q, want the rejection message", controller.changes[0].Reason)
+	}
+
+	if len(publisher.events) != 1 || publisher.events[0].Type != service.EventType(service.StatusFailedToSubmit) {
+		t.Errorf("DispatchOutbox() published %v, want a single %s event", publisher.events, service.EventType(service.StatusFailedToSubmit))
+	}
+}
+
+func TestDispatchOutboxRetries(t *testing.T) {
+	controller := &stubController{outbox: []service.OutboxMessage{
+		{ID: "1", Kind: service.OutboxKindCreate, ReqID: testReqID, Request: service.ApprovalReqType{ID: testReqID}},
+	}}
+	approvals := approval.NewFakeClient()
+	approvals.SetError(errors.New("approval service is unavailable"))
+
+	dispatchOutbox(newTestServiceWith(controller, approvals, nil), controller)
+
+	if len(controller.retried) != 1 || len(controller.deleted) != 0 {
+		t.Errorf("DispatchOutbox() retried %v and deleted %v, want all retried", controller.retried, controller.deleted)
+	}
+}
+
+func TestCreatePermit(t *testing.T) {
+	controller := &stubController{}
+	approvals := approval.NewFakeClient()
+	publisher := &recordingPublisher{}
+	s := newTestServiceWith(controller, approvals, publisher)
+
+	res, err := s.CreatePermit(context.Background(), newCreatePermitRequest("a", "b", "a"))
+	if err != nil {
+		t.Fatalf("CreatePermit() failed: %v", err)
+	}
+
+	if len(controller.requests) != 1 || controller.requests[0].ID != res.GetReqID() {
+		t.Fatalf("CreatePermit() stored requests %v, want %s", controller.requests, res.GetReqID())
+	}
+
+	wantResults := []pb.UserResultStatus{
+		pb.UserResultStatus_USER_RESULT_CREATED,
+		pb.UserResultStatus_USER_RESULT_CREATED,
+		pb.UserResultStatus_USER_RESULT_SKIPPED,
+	}
+
+	checkResults(t, res.GetResults(), wantResults)
+
+	if approval := res.GetApproval(); approval.GetStatus() != pb.SubmissionStatus_SUBMISSION_SUBMITTED {
+		t.Errorf("CreatePermit() approval status = %v, want %v", approval.GetStatus(), pb.SubmissionStatus_SUBMISSION_SUBMITTED)
+	}
+
+	// Each user gets a single permit, even if it appears in users more than once.
+	if users := controller.requests[0].To; len(users) != 2 {
+		t.Errorf("CreatePermit() stored a request to %d users, want 2", len(users))
+	}
+
+	if created := approvals.Created(); len(created) != 1 || created[0].ID != res.GetReqID() {
+		t.Errorf("CreatePermit() filed approval requests %v, want %s", created, res.GetReqID())
+	}
+
+	if len(controller.completed) != 1 || controller.completed[0] != res.GetReqID() {
+		t.Errorf("CreatePermit() completed approval requests %v, want %s", controller.completed, res.GetReqID())
+	}
+
+	if len(publisher.events) != 2 || publisher.events[0].Type != service.EventPermitCreated {
+		t.Errorf("CreatePermit() published %v, want 2 %s events", publisher.events, service.EventPermitCreated)
+	}
+}
+
+func TestCreatePermitIdempotencyKey(t *testing.T) {
+	controller := &stubController{}
+	approvals := approval.NewFakeClient()
+	s := newTestServiceWith(controller, approvals, nil)
+
+	req := newCreatePermitRequest("a")
+	req.IdempotencyKey = "key"
+
+	original, err := s.CreatePermit(context.Background(), req)
+	if err != nil {
+		t.Fatalf("CreatePermit() failed: %v", err)
+	}
+
+	retry, err := s.CreatePermit(context.Background(), req)
+	if err != nil {
+		t.Fatalf("CreatePermit() retry failed: %v", err)
+	}
+
+	if retry.GetReqID() != original.GetReqID() {
+		t.Errorf("CreatePermit() retry reqID = %s, want %s", retry.GetReqID(), original.GetReqID())
+	}
+
+	checkResults(t, retry.GetResults(), []pb.UserResultStatus{pb.UserResultStatus_USER_RESULT_CREATED})
+
+	if retry.GetApproval().GetStatus() != original.GetApproval().GetStatus() {
+		t.Errorf("CreatePermit() retry approval = %v, want %v", retry.GetApproval(), original.GetApproval())
+	}
+
+	if len(controller.requests) != 1 || len(approvals.Created()) != 1 {
+		t.Errorf(
+			"CreatePermit() retry stored %d requests and filed %d approval requests, want 1 of each",
+			len(controller.requests),
+			len(approvals.Created()),
+		)
+	}
+
+	// The key is unique among the requests of the sharer, so it can't be used for another file.
+	req.FileID = "other"
+	if _, err := s.CreatePermit(context.Background(), req); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("CreatePermit() of another file with the same key error = %v, want code %v", err, codes.InvalidArgument)
+	}
+}
+
+func TestCreatePermitRejected(t *testing.T) {
+	controller := &stubController{}
+	approvals := approval.NewFakeClient()
+	approvals.SetError(status.Error(codes.FailedPrecondition, "file is classified"))
+	s := newTestServiceWith(controller, approvals, nil)
+
+	req := newCreatePermitRequest("a")
+	req.IdempotencyKey = "key"
+
+	// A retry of the request is rejected again, just like the original request.
+	for _, attempt := range []string{"original", "retry"} {
+		_, err := s.CreatePermit(context.Background(), req)
+		if status.Code(err) != codes.FailedPrecondition {
+			t.Fatalf("CreatePermit() %s error = %v, want code %v", attempt, err, codes.FailedPrecondition)
+		}
+
+		details := status.Convert(err).Details()
+		if len(details) != 1 {
+			t.Fatalf("CreatePermit() %s error has %d details, want 1", attempt, len(details))
+		}
+
+		res, ok := details[0].(*pb.CreatePermitResponse)
+		if !ok {
+			t.Fatalf("CreatePermit() %s error detail is %T, want *pb.CreatePermitResponse", attempt, details[0])
+		}
+
+		checkResults(t, res.GetResults(), []pb.UserResultStatus{pb.UserResultStatus_USER_RESULT_FAILED})
+
+		if res.GetApproval().GetMessage() != "file is classified" {
+			t.Errorf("CreatePermit() %s approval message = %q, want the rejection message", attempt, res.GetApproval().GetMessage())
+		}
+	}
+
+	if len(controller.requests) != 1 {
+		t.Errorf("CreatePermit() stored %d requests, want 1", len(controller.requests))
+	}
+}
+
+func TestCreatePermitAlreadyApproved(t *testing.T) {
+	controller := newStubController(map[string]string{"a": service.StatusApproved})
+	s := newTestService(controller)
+
+	res, err := s.CreatePermit(context.Background(), newCreatePermitRequest("a", "b"))
+	if err != nil {
+		t.Fatalf("CreatePermit() failed: %v", err)
+	}
+
+	checkResults(t, res.GetResults(), []pb.UserResultStatus{
+		pb.UserResultStatus_USER_RESULT_ALREADY_APPROVED,
+		pb.UserResultStatus_USER_RESULT_CREATED,
+	})
+
+	if len(controller.requests) != 1 || len(controller.requests[0].To) != 1 || controller.requests[0].To[0].ID != "b" {
+		t.Fatalf("CreatePermit() stored requests %v, want a request of b only", controller.requests)
+	}
+
+	// No request is created when all of the users are already approved.
+	res, err = s.CreatePermit(context.Background(), newCreatePermitRequest("a"))
+	if err != nil {
+		t.Fatalf("CreatePermit() failed: %v", err)
+	}
+
+	checkResults(t, res.GetResults(), []pb.UserResultStatus{pb.UserResultStatus_USER_RESULT_ALREADY_APPROVED})
+
+	if res.GetReqID() != "" || res.GetApproval().GetStatus() != pb.SubmissionStatus_SUBMISSION_NONE {
+		t.Errorf("CreatePermit() = %v, want no request and no approval submission", res)
+	}
+
+	if len(controller.requests) != 1 {
+		t.Errorf("CreatePermit() stored %d requests, want 1", len(controller.requests))
+	}
+}
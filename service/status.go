@@ -0,0 +1,118 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	pb "github.com/meateam/permit-service/proto"
+)
+
+const (
+	// statusPrefix is the prefix of the names of pb.PermitStatus values.
+	statusPrefix = "STATUS_"
+
+	// StatusPending is the status of a pending request
+	StatusPending = "pending"
+
+	// StatusApproved is the status of an approved request
+	StatusApproved = "approved"
+
+	// StatusDenied is the status of a denied request
+	StatusDenied = "denied"
+
+	// StatusRevoked is the status of a permit whose approval was withdrawn
+	StatusRevoked = "revoked"
+
+	// StatusExpired is the status of a request whose validity has ended
+	StatusExpired = "expired"
+
+	// StatusCancelled is the status of a request cancelled before it was decided
+	StatusCancelled = "cancelled"
+
+	// StatusFailedToSubmit is the status of a request whose approval request was rejected by the approval service
+	StatusFailedToSubmit = "failed_to_submit"
+)
+
+// UserStatusUpdate is an update of the status of the permit of a single user of a request,
+// that applies only if the permit's status is one of FromStatuses.
+type UserStatusUpdate struct {
+	UserID       string
+	FromStatuses []string
+	Change       StatusChange
+}
+
+// statusTransitions maps each status to the statuses a permit may transition to from it,
+// statuses that are missing from the map are final.
+var statusTransitions = map[string][]string{
+	StatusPending:  {StatusApproved, StatusDenied, StatusCancelled, StatusExpired, StatusFailedToSubmit},
+	StatusApproved: {StatusRevoked, StatusExpired},
+}
+
+// StatusFromProto returns the status string of the pb.PermitStatus value.
+func StatusFromProto(permitStatus pb.PermitStatus) string {
+	return strings.ToLower(strings.TrimPrefix(permitStatus.String(), statusPrefix))
+}
+
+// ParseStatus returns the status s names, s is either a status such as "approved"
+// or the name of its pb.PermitStatus value such as "STATUS_APPROVED".
+// Returns a non-nil error if s is not a valid status.
+func ParseStatus(s string) (string, error) {
+	name := strings.ToUpper(strings.TrimSpace(s))
+	if !strings.HasPrefix(name, statusPrefix) {
+		name = statusPrefix + name
+	}
+
+	value, ok := pb.PermitStatus_value[name]
+	if !ok {
+		return "", fmt.Errorf("invalid status %q", s)
+	}
+
+	return StatusFromProto(pb.PermitStatus(value)), nil
+}
+
+// ParseDecision returns the status s names, like ParseStatus, which must be the status of an
+// approver's decision, either StatusApproved or StatusDenied. Other statuses are set by their own
+// operations, such as cancelling or revoking, and a non-nil error is returned for them.
+func ParseDecision(s string) (string, error) {
+	status, err := ParseStatus(s)
+	if err != nil {
+		return "", err
+	}
+
+	if !IsDecision(status) {
+		return "", fmt.Errorf("status must be either %s or %s, not %s", StatusApproved, StatusDenied, status)
+	}
+
+	return status, nil
+}
+
+// CanTransition returns true if a permit with the status from may be updated to the status to.
+// Updating a permit to the status it already has is allowed and has no effect.
+func CanTransition(from string, to string) bool {
+	if from == to {
+		return true
+	}
+
+	for _, next := range statusTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+
+	return false
+}
+
+// StatusesTransitioningTo returns the statuses from which a permit may be updated to the status to,
+// not including to itself.
+func StatusesTransitioningTo(to string) []string {
+	statuses := []string{}
+	for from, nexts := range statusTransitions {
+		for _, next := range nexts {
+			if next == to {
+				statuses = append(statuses, from)
+			}
+		}
+	}
+
+	return statuses
+}
@@ -0,0 +1,127 @@
+package service
+
+import (
+	"sort"
+	"testing"
+)
+
+func TestCanTransition(t *testing.T) {
+	tests := []struct {
+		from string
+		to   string
+		want bool
+	}{
+		{StatusPending, StatusApproved, true},
+		{StatusPending, StatusDenied, true},
+		{StatusPending, StatusCancelled, true},
+		{StatusPending, StatusExpired, true},
+		{StatusPending, StatusFailedToSubmit, true},
+		{StatusPending, StatusRevoked, false},
+		{StatusApproved, StatusRevoked, true},
+		{StatusApproved, StatusExpired, true},
+		{StatusApproved, StatusDenied, false},
+		{StatusApproved, StatusPending, false},
+		{StatusDenied, StatusApproved, false},
+		{StatusRevoked, StatusApproved, false},
+		{StatusCancelled, StatusPending, false},
+		{StatusFailedToSubmit, StatusPending, false},
+		{StatusExpired, StatusApproved, false},
+		{StatusApproved, StatusApproved, true},
+		{StatusRevoked, StatusRevoked, true},
+	}
+
+	for _, tt := range tests {
+		if got := CanTransition(tt.from, tt.to); got != tt.want {
+			t.Errorf("CanTransition(%q, %q) = %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
+
+func TestStatusesTransitioningTo(t *testing.T) {
+	tests := []struct {
+		to   string
+		want []string
+	}{
+		{StatusApproved, []string{StatusPending}},
+		{StatusDenied, []string{StatusPending}},
+		{StatusCancelled, []string{StatusPending}},
+		{StatusFailedToSubmit, []string{StatusPending}},
+		{StatusRevoked, []string{StatusApproved}},
+		{StatusExpired, []string{StatusApproved, StatusPending}},
+		{StatusPending, []string{}},
+	}
+
+	for _, tt := range tests {
+		got := StatusesTransitioningTo(tt.to)
+		sort.Strings(got)
+		if !equalStrings(got, tt.want) {
+			t.Errorf("StatusesTransitioningTo(%q) = %v, want %v", tt.to, got, tt.want)
+		}
+
+		for _, from := range got {
+			if !CanTransition(from, tt.to) {
+				t.Errorf("StatusesTransitioningTo(%q) has %q, which cannot transition to it", tt.to, from)
+			}
+		}
+	}
+}
+
+func TestParseStatus(t *testing.T) {
+	tests := []struct {
+		s       string
+		want    string
+		wantErr bool
+	}{
+		{"approved", StatusApproved, false},
+		{"STATUS_DENIED", StatusDenied, false},
+		{" Pending ", StatusPending, false},
+		{"status_revoked", StatusRevoked, false},
+		{"unknown", "", true},
+		{"", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseStatus(tt.s)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseStatus(%q) = %q, %v, want %q, error %v", tt.s, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestParseDecision(t *testing.T) {
+	tests := []struct {
+		s       string
+		want    string
+		wantErr bool
+	}{
+		{"approved", StatusApproved, false},
+		{"STATUS_DENIED", StatusDenied, false},
+		{"pending", "", true},
+		{"revoked", "", true},
+		{"cancelled", "", true},
+		{"expired", "", true},
+		{"unknown", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseDecision(tt.s)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseDecision(%q) = %q, %v, want %q, error %v", tt.s, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+// equalStrings returns true if a and b hold the same strings in the same order.
+func equalStrings(a []string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
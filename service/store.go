@@ -7,8 +7,9 @@ import (
 // Store is an interface for handling the storing of permissions.
 type Store interface {
 	Create(ctx context.Context, permit Permit) (Permit, error)
+	CreateMany(ctx context.Context, permits []Permit) error
 	Get(ctx context.Context, filter interface{}) (Permit, error)
 	GetAll(ctx context.Context, filter interface{}) ([]Permit, error)
-	Delete(ctx context.Context, filter interface{}) (Permit, error)
+	UpdateStatus(ctx context.Context, filter interface{}, change StatusChange) (int64, error)
 	HealthCheck(ctx context.Context) (bool, error)
 }
@@ -0,0 +1,154 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	spb "github.com/meateam/spike-service/proto/spike-service"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	// tokenRefreshMargin is how long before its expiry a cached token is refreshed,
+	// the cached token keeps being used while it's refreshed in the background.
+	tokenRefreshMargin = 30 * time.Second
+
+	// tokenRefreshTimeout is the timeout of fetching a token from spike, which isn't bound to the
+	// context of the caller since the fetched token is shared by all concurrent callers.
+	tokenRefreshTimeout = 10 * time.Second
+
+	// tokenKey is the singleflight key of fetching a token.
+	tokenKey = "token"
+)
+
+// TokenSource is an interface for getting the token to authenticate with to other services.
+type TokenSource interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// TokenCache is a TokenSource which caches the spike token of grantType and audience until shortly
+// before it expires, refreshing it in the background and coalescing concurrent fetches into a single
+// request to spike.
+type TokenCache struct {
+	client    spb.SpikeClient
+	grantType string
+	audience  string
+	ttl       time.Duration
+	logger    *logrus.Logger
+	group     singleflight.Group
+
+	mu        sync.RWMutex
+	token     string
+	expiresAt time.Time
+}
+
+// NewTokenCache creates a TokenCache of the tokens fetched with client. Tokens whose expiry
+// can't be parsed from their JWT exp claim are considered valid for ttl.
+func NewTokenCache(
+	client spb.SpikeClient,
+	grantType string,
+	audience string,
+	ttl time.Duration,
+	logger *logrus.Logger,
+) *TokenCache {
+	return &TokenCache{
+		client:    client,
+		grantType: grantType,
+		audience:  audience,
+		ttl:       ttl,
+		logger:    logger,
+	}
+}
+
+// Token returns a valid spike token, fetching one only if there is no cached token
+// or the cached token has expired.
+func (c *TokenCache) Token(ctx context.Context) (string, error) {
+	now := time.Now()
+
+	c.mu.RLock()
+	token, expiresAt := c.token, c.expiresAt
+	c.mu.RUnlock()
+
+	if token != "" && now.Before(expiresAt) {
+		if !now.Before(expiresAt.Add(-tokenRefreshMargin)) {
+			c.group.DoChan(tokenKey, c.refresh)
+		}
+
+		return token, nil
+	}
+
+	result := c.group.DoChan(tokenKey, c.refresh)
+	select {
+	case <-ctx.Done():
+		return "", ctx.Err()
+	case res := <-result:
+		if res.Err != nil {
+			return "", res.Err
+		}
+
+		return res.Val.(string), nil
+	}
+}
+
+// refresh fetches a token from spike and caches it.
+func (c *TokenCache) refresh() (interface{}, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), tokenRefreshTimeout)
+	defer cancel()
+
+	res, err := c.client.GetSpikeToken(ctx, &spb.GetSpikeTokenRequest{
+		GrantType: c.grantType,
+		Audience:  c.audience,
+	})
+	if err != nil {
+		c.logger.Errorf("failed refreshing spike token: %v", err)
+		return nil, unavailableError(err, "spike service")
+	}
+
+	token := res.GetToken()
+	now := time.Now()
+	expiresAt, err := tokenExpiry(token)
+	if err != nil {
+		expiresAt = now.Add(c.ttl)
+	}
+
+	c.mu.Lock()
+	c.token = token
+	c.expiresAt = expiresAt
+	c.mu.Unlock()
+
+	return token, nil
+}
+
+// tokenExpiry returns the time of the exp claim of the JWT token.
+// Returns a non-nil error if token isn't a JWT or has no exp claim.
+func tokenExpiry(token string) (time.Time, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}, fmt.Errorf("token is not a JWT")
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed decoding token payload: %v", err)
+	}
+
+	var claims struct {
+		Exp int64 `json:"exp"`
+	}
+
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return time.Time{}, fmt.Errorf("failed parsing token claims: %v", err)
+	}
+
+	if claims.Exp == 0 {
+		return time.Time{}, fmt.Errorf("token has no exp claim")
+	}
+
+	return time.Unix(claims.Exp, 0), nil
+}
@@ -0,0 +1,35 @@
+package service
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+)
+
+func TestTokenExpiry(t *testing.T) {
+	jwt := func(payload string) string {
+		return "header." + base64.RawURLEncoding.EncodeToString([]byte(payload)) + ".signature"
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		want    time.Time
+		wantErr bool
+	}{
+		{"exp claim", jwt(`{"exp":1700000000,"sub":"permit-service"}`), time.Unix(1700000000, 0), false},
+		{"padded payload", "header." + base64.URLEncoding.EncodeToString([]byte(`{"exp":1}`)) + ".signature", time.Unix(1, 0), false},
+		{"no exp claim", jwt(`{"sub":"permit-service"}`), time.Time{}, true},
+		{"invalid claims", jwt(`not json`), time.Time{}, true},
+		{"invalid payload", "header.!!!.signature", time.Time{}, true},
+		{"opaque token", "2YotnFZFEjr1zCsicMWpAA", time.Time{}, true},
+		{"empty token", "", time.Time{}, true},
+	}
+
+	for _, tt := range tests {
+		got, err := tokenExpiry(tt.token)
+		if (err != nil) != tt.wantErr || !got.Equal(tt.want) {
+			t.Errorf("%s: tokenExpiry() = %v, %v, want %v, error %v", tt.name, got, err, tt.want, tt.wantErr)
+		}
+	}
+}